@@ -67,6 +67,138 @@ func Run(args []string) int {
 			exec: commands.Delete,
 			help: "delete notes",
 		},
+		"trash": {
+			exec: commands.Trash,
+			help: "list or empty deleted notes",
+		},
+		"restore": {
+			exec: commands.Restore,
+			help: "restore a deleted note",
+		},
+		"prune": {
+			exec: commands.Prune,
+			help: "archive or delete notes of branches that no longer exist",
+		},
+		"archive": {
+			exec: commands.Archive,
+			help: "archive notes of merged branches",
+		},
+		"rollup": {
+			exec: commands.Rollup,
+			help: "add merged branch notes to the project changelog",
+		},
+		"todo": {
+			exec: commands.Todo,
+			help: "list open checklist items of the notes",
+		},
+		"agenda": {
+			exec: commands.Agenda,
+			help: "list overdue and upcoming dated items of the notes",
+		},
+		"list": {
+			exec: commands.List,
+			help: "list notes",
+		},
+		"search": {
+			exec: commands.Search,
+			help: "search notes for a text",
+		},
+		"tags": {
+			exec: commands.Tags,
+			help: "list tags used in the notes",
+		},
+		"links": {
+			exec: commands.Links,
+			help: "list links from the note to other notes",
+		},
+		"backlinks": {
+			exec: commands.Backlinks,
+			help: "list links from other notes to the note",
+		},
+		"graph": {
+			exec: commands.Graph,
+			help: "print the graph of notes as DOT or JSON",
+		},
+		"meta": {
+			exec: commands.Meta,
+			help: "print or change the frontmatter of the note",
+		},
+		"mv": {
+			exec: commands.Move,
+			help: "move a note to another project/branch",
+		},
+		"context": {
+			exec: commands.Context,
+			help: "write a snapshot of the branch git state into the note",
+		},
+		"refs": {
+			exec: commands.Refs,
+			help: "check, pin and show code references of the notes",
+		},
+		"attach": {
+			exec: commands.Attach,
+			help: "attach a file to the note",
+		},
+		"attachments": {
+			exec: commands.Attachments,
+			help: "list attached files and the notes linking to them",
+		},
+		"outline": {
+			exec: commands.Outline,
+			help: "print the outline of the headings of the notes",
+		},
+		"stats": {
+			exec: commands.Stats,
+			help: "print notebook statistics and an activity heatmap",
+		},
+		"standup": {
+			exec: commands.Standup,
+			help: "print what changed in the notes since yesterday",
+		},
+		"time": {
+			exec: commands.Time,
+			help: "report the time spent on each branch",
+		},
+		"pin": {
+			exec: commands.Pin,
+			help: "pin a note to the dashboard",
+		},
+		"unpin": {
+			exec: commands.Unpin,
+			help: "unpin a note from the dashboard",
+		},
+		"dashboard": {
+			exec: commands.Dashboard,
+			help: "show pinned and recently edited notes",
+		},
+		"status": {
+			exec: commands.Status,
+			help: "print or set the status of the note",
+		},
+		"board": {
+			exec: commands.Board,
+			help: "show the notes in columns by status",
+		},
+		"branch-desc": {
+			exec: commands.BranchDesc,
+			help: "sync a note section with the git branch description",
+		},
+		"commit-msg": {
+			exec: commands.CommitMsg,
+			help: "draft the commit message from the note, for prepare-commit-msg hooks",
+		},
+		"hooks": {
+			exec: commands.Hooks,
+			help: "install or uninstall the git hooks of the working repository",
+		},
+		"pr-body": {
+			exec: commands.PRBody,
+			help: "render the note into a pull request description",
+		},
+		"cp": {
+			exec: commands.Copy,
+			help: "copy a note to another project/branch",
+		},
 	}
 
 	subcommandIndex := 1
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Agenda(app *gn.GN, args []string) int {
+	// gn agenda
+	var days int
+	var ics string
+	var filter gn.NoteFilter
+	agendaCmd := flag.NewFlagSet("agenda", flag.ExitOnError)
+	agendaCmd.StringVar(&filter.Project, "p", app.Project, "only list items of this project")
+	agendaCmd.StringVar(&filter.Tag, "tag", "", "only list items of notes with this tag")
+	agendaCmd.IntVar(&days, "days", 14, "number of days ahead to list upcoming items")
+	agendaCmd.StringVar(&ics, "ics", "", "export all dated items to this iCalendar file ('-' for stdout)")
+	agendaCmd.Usage = func() {
+		fmt.Println("Lists overdue and upcoming note lines annotated with @due(2026-11-02) or @remind(2026-11-02 09:30).")
+		agendaCmd.PrintDefaults()
+	}
+
+	if err := agendaCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing agenda command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	items, err := app.Agenda(filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading agenda: %s\n", err.Error())
+		return 1
+	}
+
+	if ics != "" {
+		return exportICS(items, ics)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	until := today.AddDate(0, 0, days+1)
+
+	var overdue, upcoming []gn.AgendaItem
+	for _, item := range items {
+		switch {
+		case item.AllDay && item.Date.Before(today), !item.AllDay && item.Date.Before(now):
+			overdue = append(overdue, item)
+		case item.Date.Before(until):
+			upcoming = append(upcoming, item)
+		}
+	}
+
+	printAgendaItems("Overdue", overdue)
+	printAgendaItems("Upcoming", upcoming)
+	return 0
+}
+
+func printAgendaItems(title string, items []gn.AgendaItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Println(title)
+	for _, item := range items {
+		date := item.Date.Format("2006-01-02 15:04")
+		if item.AllDay {
+			date = item.Date.Format("2006-01-02      ")
+		}
+		fmt.Printf("  %s  %-6s  %s\t%s\n", date, item.Kind, item.Text, item.Ref())
+	}
+}
+
+func exportICS(items []gn.AgendaItem, path string) int {
+	out := os.Stdout
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error creating %s: %s\n", path, err.Error())
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := gn.WriteICS(out, items, time.Now()); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing iCalendar file: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
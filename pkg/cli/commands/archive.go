@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Archive(app *gn.GN, args []string) int {
+	// gn archive
+	var merged, list, dryRun bool
+	archiveCmd := flag.NewFlagSet("archive", flag.ExitOnError)
+	archiveCmd.BoolVar(&merged, "merged", false, "archive notes of the current project's branches merged into the default branch")
+	archiveCmd.BoolVar(&dryRun, "n", false, "with -merged, only list the notes that would be archived")
+	archiveCmd.BoolVar(&list, "list", false, "list archived notes")
+	archiveCmd.StringVar(&app.Project, "p", app.Project, "project to archive or list notes")
+	archiveCmd.Usage = func() {
+		fmt.Println("Archives notes of merged branches, or lists archived notes. Example: gn archive -merged")
+		archiveCmd.PrintDefaults()
+	}
+
+	if err := archiveCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing archive command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkArchiveParams(merged, list); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	if list {
+		archived, err := app.ListArchive(app.Project)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error listing archived notes: %s\n", err.Error())
+			return 1
+		}
+		for _, a := range archived {
+			printArchivedNote(a)
+		}
+		return 0
+	}
+
+	archived, err := app.ArchiveMerged(dryRun)
+	for _, a := range archived {
+		printArchivedNote(a)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error archiving notes: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+func printArchivedNote(a gn.ArchivedNote) {
+	if a.MergeCommit == "" {
+		fmt.Printf("%s\n", a.Ref())
+		return
+	}
+	fmt.Printf("%s\tmerged in %.8s on %s\n", a.Ref(), a.MergeCommit, a.Merged.Local().Format(time.DateOnly))
+}
+
+func checkArchiveParams(merged bool, list bool) error {
+	if merged == list {
+		return errflags.New("one of -merged or -list is necessary", errflags.BadParameter)
+	}
+
+	return nil
+}
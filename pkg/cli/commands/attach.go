@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Attach(app *gn.GN, args []string) int {
+	// gn attach <file>
+	attachCmd := flag.NewFlagSet("attach", flag.ExitOnError)
+	attachCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	attachCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note")
+	attachCmd.Usage = func() {
+		fmt.Println("Copies a file into the project assets, links it from the note and commits both. Usage: gn attach [flags] <file>")
+		attachCmd.PrintDefaults()
+	}
+
+	if err := attachCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing attach command arguments: %s\n", err.Error())
+		return 1
+	}
+	if attachCmd.NArg() != 1 {
+		attachCmd.Usage()
+		return 1
+	}
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	link, err := app.Attach(attachCmd.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error attaching file: %s\n", err.Error())
+		return 1
+	}
+	fmt.Println(link)
+
+	return 0
+}
+
+func Attachments(app *gn.GN, args []string) int {
+	// gn attachments
+	var project string
+	var unreferenced bool
+	attachmentsCmd := flag.NewFlagSet("attachments", flag.ExitOnError)
+	attachmentsCmd.StringVar(&project, "p", app.Project, "only list attachments of this project")
+	attachmentsCmd.BoolVar(&unreferenced, "unreferenced", false, "only list attachments no note links to")
+	attachmentsCmd.Usage = func() {
+		fmt.Println("Lists the files attached to the notes and the notes linking to them.")
+		attachmentsCmd.PrintDefaults()
+	}
+
+	if err := attachmentsCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing attachments command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	attachments, err := app.Attachments(project)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing attachments: %s\n", err.Error())
+		return 1
+	}
+
+	for _, a := range attachments {
+		if unreferenced && len(a.Notes) > 0 {
+			continue
+		}
+		notes := []string{}
+		for _, n := range a.Notes {
+			notes = append(notes, n.String())
+		}
+		if len(notes) == 0 {
+			notes = append(notes, "unreferenced")
+		}
+		fmt.Printf("%s/%s\t%d\t%s\n", a.Project, a.Name, a.Size, strings.Join(notes, ", "))
+	}
+
+	return 0
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Board(app *gn.GN, args []string) int {
+	// gn board
+	var filter gn.NoteFilter
+	var all bool
+	var width int
+	boardCmd := flag.NewFlagSet("board", flag.ExitOnError)
+	boardCmd.StringVar(&filter.Project, "p", app.Project, "only include notes of this project")
+	boardCmd.StringVar(&filter.Tag, "tag", "", "only include notes with this tag")
+	boardCmd.BoolVar(&all, "all", false, "also include notes without status")
+	boardCmd.IntVar(&width, "width", 24, "width of each column")
+	boardCmd.Usage = func() {
+		fmt.Println("Shows the notes in columns by status.")
+		boardCmd.PrintDefaults()
+	}
+
+	if err := boardCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing board command arguments: %s\n", err.Error())
+		return 1
+	}
+	if width < 4 {
+		fmt.Fprintf(os.Stderr, "error validating parameters: width must be at least 4\n")
+		return 1
+	}
+
+	columns, err := app.Board(filter, all)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading board: %s\n", err.Error())
+		return 1
+	}
+
+	fmt.Print(renderBoard(columns, width))
+	return 0
+}
+
+// renderBoard renders the columns side by side, each width characters wide
+func renderBoard(columns []gn.BoardColumn, width int) string {
+	cell := func(s string) string {
+		if len([]rune(s)) > width {
+			s = string([]rune(s)[:width-1]) + "…"
+		}
+		return s + strings.Repeat(" ", width-len([]rune(s)))
+	}
+
+	rows := 0
+	header := []string{}
+	rule := []string{}
+	for _, c := range columns {
+		status := c.Status
+		if status == "" {
+			status = "no status"
+		}
+		header = append(header, cell(fmt.Sprintf("%s (%d)", status, len(c.Notes))))
+		rule = append(rule, strings.Repeat("-", width))
+		if len(c.Notes) > rows {
+			rows = len(c.Notes)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strings.TrimRight(strings.Join(header, " | "), " ") + "\n")
+	sb.WriteString(strings.Join(rule, "-+-") + "\n")
+	for i := 0; i < rows; i++ {
+		row := []string{}
+		for _, c := range columns {
+			note := ""
+			if i < len(c.Notes) {
+				note = c.Notes[i].String()
+			}
+			row = append(row, cell(note))
+		}
+		sb.WriteString(strings.TrimRight(strings.Join(row, " | "), " ") + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderBoard(t *testing.T) {
+	columns := []gn.BoardColumn{
+		{Status: "draft", Notes: []gn.NoteRef{{Project: "billing", Branch: "feat/refunds"}, {Project: "shop", Branch: "main"}}},
+		{Status: "done", Notes: []gn.NoteRef{{Project: "billing", Branch: "main"}}},
+	}
+
+	expected := "" +
+		"draft (2)    | done (1)\n" +
+		"-------------+-------------\n" +
+		"billing/fea… | billing/main\n" +
+		"shop/main    |\n"
+	assert.Equal(t, expected, renderBoard(columns, 12))
+}
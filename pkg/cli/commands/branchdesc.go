@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func BranchDesc(app *gn.GN, args []string) int {
+	// gn branch-desc push|pull
+	usage := "Syncs a section of the note (see branch-desc-section in the config file) with the git branch description. Usage: gn branch-desc push|pull [flags]"
+	if len(args) < 3 || (args[2] != "push" && args[2] != "pull") {
+		fmt.Println(usage)
+		return 1
+	}
+
+	direction := args[2]
+	descCmd := flag.NewFlagSet("branch-desc "+direction, flag.ExitOnError)
+	descCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	descCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note and of the working repository")
+	descCmd.Usage = func() {
+		fmt.Println(usage)
+		descCmd.PrintDefaults()
+	}
+
+	if err := descCmd.Parse(args[3:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing branch-desc arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	var err error
+	if direction == "push" {
+		_, err = app.PushBranchDesc()
+	} else {
+		_, err = app.PullBranchDesc()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error syncing branch description: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
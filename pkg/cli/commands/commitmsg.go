@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func CommitMsg(app *gn.GN, args []string) int {
+	// gn commit-msg <file> [source]
+	var clear bool
+	msgCmd := flag.NewFlagSet("commit-msg", flag.ExitOnError)
+	msgCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	msgCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note")
+	msgCmd.BoolVar(&app.CommitMsgTrailer, "trailer", app.CommitMsgTrailer, "add a Gitnotes-Ref trailer with the revision of the notes")
+	msgCmd.BoolVar(&clear, "clear", false, "empty the commit message section of the note if the last commit used it, for post-commit hooks")
+	msgCmd.Usage = func() {
+		fmt.Println("Drafts the commit message from a section of the note (see commit-msg-section in the config file). Usage: gn commit-msg [flags] <file> [source], from a prepare-commit-msg hook")
+		msgCmd.PrintDefaults()
+	}
+
+	if err := msgCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing commit-msg arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	if clear {
+		if _, err := app.ClearCommitMsg(); err != nil {
+			fmt.Fprintf(os.Stderr, "error clearing commit message section: %s\n", err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	if msgCmd.NArg() < 1 {
+		msgCmd.Usage()
+		return 1
+	}
+	source := ""
+	if msgCmd.NArg() > 1 {
+		source = msgCmd.Arg(1)
+	}
+
+	if _, err := app.CommitMsg(msgCmd.Arg(0), source); err != nil {
+		fmt.Fprintf(os.Stderr, "error preparing commit message: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Context(app *gn.GN, args []string) int {
+	// gn context
+	var show bool
+	contextCmd := flag.NewFlagSet("context", flag.ExitOnError)
+	contextCmd.BoolVar(&show, "print", false, "print the snapshot instead of writing it into the note")
+	contextCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	contextCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note and of the snapshot")
+	contextCmd.Usage = func() {
+		fmt.Println("Writes a snapshot of the branch (commits and diffstat since the default branch, upstream and uncommitted files) into the note.")
+		contextCmd.PrintDefaults()
+	}
+
+	if err := contextCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing context command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	c, err := app.GitContext()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading git context: %s\n", err.Error())
+		return 1
+	}
+
+	if show {
+		fmt.Print(c.Markdown())
+		return 0
+	}
+
+	if err := app.InsertContext(c); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing git context: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Dashboard(app *gn.GN, args []string) int {
+	// gn dashboard
+	var days int
+	dashboardCmd := flag.NewFlagSet("dashboard", flag.ExitOnError)
+	dashboardCmd.IntVar(&days, "days", 7, "also show notes edited in the last days")
+	dashboardCmd.Usage = func() {
+		fmt.Println("Shows the pinned notes and the recently edited ones, with their status and open todos.")
+		dashboardCmd.PrintDefaults()
+	}
+
+	if err := dashboardCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing dashboard command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	d, err := app.Dashboard(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading dashboard: %s\n", err.Error())
+		return 1
+	}
+
+	fmt.Println("Pinned")
+	printDashboardNotes(d.Pinned)
+	fmt.Printf("\nEdited in the last %d days\n", days)
+	printDashboardNotes(d.Recent)
+
+	return 0
+}
+
+func printDashboardNotes(notes []gn.DashboardNote) {
+	for _, n := range notes {
+		status := n.Status
+		if status == "" {
+			status = "-"
+		}
+		fmt.Printf("  %s\t%s\t%d todos\tedited %s\n", n.NoteRef, status, n.OpenTodos, n.Modified.Format("2006-01-02 15:04"))
+	}
+}
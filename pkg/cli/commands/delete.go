@@ -1,20 +1,26 @@
 package commands
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
 	"github.com/mcbattirola/gitnotes/pkg/gn"
 )
 
 func Delete(app *gn.GN, args []string) int {
 	// gn delete
+	var all, yes bool
 	deleteCmd := flag.NewFlagSet("delete", flag.ExitOnError)
 	deleteCmd.StringVar(&app.Project, "p", app.Project, "project to delete notes")
 	deleteCmd.StringVar(&app.Branch, "b", app.Branch, "branch to delete notes")
+	deleteCmd.BoolVar(&all, "all", false, "delete all notes of the project")
+	deleteCmd.BoolVar(&yes, "y", false, "do not ask for confirmation when deleting all notes of a project")
 	deleteCmd.Usage = func() {
-		fmt.Println("delete notes")
+		fmt.Println("delete notes. Deleted notes are moved to the trash and can be restored with 'gn restore'")
 		deleteCmd.PrintDefaults()
 	}
 
@@ -22,10 +28,57 @@ func Delete(app *gn.GN, args []string) int {
 		fmt.Fprintf(os.Stderr, "error parsing delete command arguments: %s", err.Error())
 		return 1
 	}
-	if err := app.Delete(); err != nil {
+	if err := checkDeleteParams(app, all); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	if all {
+		return deleteProject(app, yes)
+	}
+
+	item, err := app.Delete()
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "error while deleting note: %s\n", err.Error())
 		return 1
 	}
+	fmt.Printf("moved %s to trash (id %s)\n", item.Ref(), item.ID)
+
+	return 0
+}
+
+func deleteProject(app *gn.GN, yes bool) int {
+	if !yes {
+		fmt.Printf("Delete all notes of project %s? [y/N]: ", app.Project)
+		reader := bufio.NewReader(os.Stdin)
+		answer, _ := reader.ReadString('\n')
+		if strings.ToLower(strings.TrimSpace(answer)) != "y" {
+			fmt.Println("aborted")
+			return 1
+		}
+	}
+
+	items, err := app.DeleteProject(app.Project)
+	for _, item := range items {
+		fmt.Printf("moved %s to trash (id %s)\n", item.Ref(), item.ID)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error while deleting notes: %s\n", err.Error())
+		return 1
+	}
 
 	return 0
 }
+
+func checkDeleteParams(app *gn.GN, all bool) error {
+	if all {
+		if app.Project == "" {
+			return errflags.New("project is necessary when deleting all notes", errflags.BadParameter)
+		}
+		if app.Branch != "" {
+			return errflags.New("branch can't be used when deleting all notes", errflags.BadParameter)
+		}
+	}
+
+	return nil
+}
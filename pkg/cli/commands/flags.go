@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// parseDuration parses a duration as time.ParseDuration does,
+// but also accepts days (e.g. 30d), which time.ParseDuration does not
+func parseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, errflags.New("invalid number of days: "+s, errflags.BadParameter)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errflags.Flag(err, errflags.BadParameter)
+	}
+	return d, nil
+}
+
+// parseTime parses a point in time given as a date (2006-01-02), as
+// today or yesterday, or as a duration before now (e.g. 30d, 12h)
+func parseTime(s string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch s {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	if t, err := time.ParseInLocation(time.DateOnly, s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	d, err := parseDuration(s)
+	if err != nil {
+		return time.Time{}, errflags.New("invalid time "+s+", use a date (2006-01-02), today, yesterday or a duration (30d)", errflags.BadParameter)
+	}
+	return now.Add(-d), nil
+}
+
+// parseTimeRange parses the since and until flags. Empty values
+// are returned as zero times, meaning no limit
+func parseTimeRange(since string, until string, now time.Time) (time.Time, time.Time, error) {
+	var from, to time.Time
+	var err error
+	if since != "" {
+		if from, err = parseTime(since, now); err != nil {
+			return from, to, err
+		}
+	}
+	if until != "" {
+		if to, err = parseTime(until, now); err != nil {
+			return from, to, err
+		}
+		// a date includes the whole day
+		if _, dateErr := time.Parse(time.DateOnly, until); dateErr == nil || until == "today" || until == "yesterday" {
+			to = to.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+	return from, to, nil
+}
@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDuration(t *testing.T) {
+	tt := []struct {
+		name      string
+		input     string
+		expected  time.Duration
+		expectErr bool
+	}{
+		{name: "it accepts days", input: "30d", expected: 30 * 24 * time.Hour},
+		{name: "it accepts go durations", input: "12h", expected: 12 * time.Hour},
+		{name: "it does not accept negative days", input: "-1d", expectErr: true},
+		{name: "it does not accept invalid input", input: "week", expectErr: true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := parseDuration(tc.input)
+			if tc.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, d)
+		})
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	tt := []struct {
+		name      string
+		input     string
+		expected  time.Time
+		expectErr bool
+	}{
+		{name: "it accepts dates", input: "2026-10-01", expected: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "it accepts today", input: "today", expected: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+		{name: "it accepts yesterday", input: "yesterday", expected: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		{name: "it accepts durations", input: "2d", expected: time.Date(2026, 10, 17, 15, 30, 0, 0, time.UTC)},
+		{name: "it does not accept invalid input", input: "last week", expectErr: true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := parseTime(tc.input, now)
+			if tc.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, parsed)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Graph(app *gn.GN, args []string) int {
+	// gn graph
+	var filter gn.NoteFilter
+	var format, since, until string
+	graphCmd := flag.NewFlagSet("graph", flag.ExitOnError)
+	graphCmd.StringVar(&filter.Project, "p", app.Project, "only include notes of this project")
+	graphCmd.StringVar(&filter.Tag, "tag", "", "only include notes with this tag")
+	graphCmd.StringVar(&format, "format", "dot", "output format: dot or json")
+	graphCmd.StringVar(&since, "since", "", "only include notes modified since this date (2006-01-02, yesterday or 30d)")
+	graphCmd.StringVar(&until, "until", "", "only include notes modified until this date")
+	graphCmd.Usage = func() {
+		fmt.Println("Prints the graph of projects and notes, with their links and shared ticket keys. Example: gn graph | dot -Tsvg > notes.svg")
+		graphCmd.PrintDefaults()
+	}
+
+	if err := graphCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing graph command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkFormat(format, "dot", "json"); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	var err error
+	filter.Since, filter.Until, err = parseTimeRange(since, until, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	g, err := app.Graph(filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error building graph: %s\n", err.Error())
+		return 1
+	}
+
+	if format == "json" {
+		err = printJSON(g)
+	} else {
+		err = g.WriteDOT(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error writing graph: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+// checkFormat validates an output format flag
+func checkFormat(format string, formats ...string) error {
+	for _, f := range formats {
+		if format == f {
+			return nil
+		}
+	}
+	return errflags.New(fmt.Sprintf("unknown format %s, expected one of %v", format, formats), errflags.BadParameter)
+}
+
+// printJSON prints v as indented JSON to stdout
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
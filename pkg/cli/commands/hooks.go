@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Hooks(app *gn.GN, args []string) int {
+	// gn hooks install|uninstall|run <hook> [args]
+	usage := "Installs or removes the post-checkout, prepare-commit-msg, post-commit and pre-push git hooks of the working repository. Usage: gn hooks install|uninstall"
+	if len(args) < 3 {
+		fmt.Println(usage)
+		return 1
+	}
+
+	switch args[2] {
+	case "install", "uninstall":
+	case "run":
+		return runHook(app, args[3:])
+	default:
+		fmt.Println(usage)
+		return 1
+	}
+
+	action := args[2]
+	hooksCmd := flag.NewFlagSet("hooks "+action, flag.ExitOnError)
+	hooksCmd.Usage = func() {
+		fmt.Println(usage)
+		hooksCmd.PrintDefaults()
+	}
+	if err := hooksCmd.Parse(args[3:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing hooks arguments: %s\n", err.Error())
+		return 1
+	}
+
+	var changes []gn.HookChange
+	var err error
+	if action == "install" {
+		changes, err = app.InstallHooks()
+	} else {
+		changes, err = app.UninstallHooks()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error during hooks %s: %s\n", action, err.Error())
+		return 1
+	}
+
+	for _, c := range changes {
+		line := fmt.Sprintf("%sed %s", action, c.Path)
+		if c.Chained {
+			line += " (chained with the existing hook)"
+		}
+		fmt.Println(line)
+	}
+	return 0
+}
+
+// runHook runs the gn part of a git hook. Errors are reported but
+// never fail the hook, so gn does not get in the way of git
+func runHook(app *gn.GN, args []string) int {
+	if len(args) < 1 {
+		fmt.Println("Runs a git hook. Usage: gn hooks run <hook> [args], called by the hooks gn installs")
+		return 1
+	}
+
+	hook, args := args[0], args[1:]
+	switch hook {
+	case "post-checkout":
+		// args are the previous HEAD, the new HEAD and 1 for branch checkouts
+		if len(args) < 3 || args[2] != "1" {
+			return 0
+		}
+		s, err := app.Summary()
+		if err != nil {
+			if !errflags.HasFlag(err, errflags.NotFound) {
+				fmt.Fprintf(os.Stderr, "gn: error reading note: %s\n", err.Error())
+			}
+			return 0
+		}
+		fmt.Println(s.String())
+	case "prepare-commit-msg":
+		// args are the message file, its source and the commit
+		if len(args) < 1 {
+			return 0
+		}
+		source := ""
+		if len(args) > 1 {
+			source = args[1]
+		}
+		if _, err := app.CommitMsg(args[0], source); err != nil {
+			fmt.Fprintf(os.Stderr, "gn: error preparing commit message: %s\n", err.Error())
+		}
+	case "post-commit":
+		if _, err := app.ClearCommitMsg(); err != nil && !errflags.HasFlag(err, errflags.NotFound) {
+			fmt.Fprintf(os.Stderr, "gn: error clearing commit message section: %s\n", err.Error())
+		}
+	case "pre-push":
+		if err := app.Push(); err != nil {
+			if !errflags.HasFlag(err, errflags.NoRemote) {
+				fmt.Fprintf(os.Stderr, "gn: error pushing notes: %s\n", err.Error())
+			}
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "gn: unknown hook %s\n", hook)
+		return 1
+	}
+
+	return 0
+}
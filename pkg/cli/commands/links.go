@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Links(app *gn.GN, args []string) int {
+	// gn links
+	var broken bool
+	linksCmd := flag.NewFlagSet("links", flag.ExitOnError)
+	linksCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	linksCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note")
+	linksCmd.BoolVar(&broken, "broken", false, "list broken links of all notes, or of the project given with -p")
+	linksCmd.Usage = func() {
+		fmt.Println("Lists the [[project/branch]] and [[project]] links of the note.")
+		linksCmd.PrintDefaults()
+	}
+
+	if err := linksCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing links command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	if broken {
+		links, err := app.BrokenLinks(gn.NoteFilter{Project: app.Project})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading links: %s\n", err.Error())
+			return 1
+		}
+		for _, l := range links {
+			fmt.Printf("%s:%d\t%s\n", l.From, l.Line, l.Target)
+		}
+		return 0
+	}
+
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	links, err := app.Links()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading links: %s\n", err.Error())
+		return 1
+	}
+	for _, l := range links {
+		status := ""
+		if l.Broken {
+			status = " (broken)"
+		}
+		fmt.Printf("%d\t%s%s\n", l.Line, l.Target, status)
+	}
+
+	return 0
+}
+
+func Backlinks(app *gn.GN, args []string) int {
+	// gn backlinks
+	backlinksCmd := flag.NewFlagSet("backlinks", flag.ExitOnError)
+	backlinksCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	backlinksCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note")
+	backlinksCmd.Usage = func() {
+		fmt.Println("Lists the notes linking to the note or to its project.")
+		backlinksCmd.PrintDefaults()
+	}
+
+	if err := backlinksCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing backlinks command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	links, err := app.Backlinks()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading backlinks: %s\n", err.Error())
+		return 1
+	}
+	for _, l := range links {
+		fmt.Printf("%s:%d\t[[%s]]\n", l.From, l.Line, l.Target)
+	}
+
+	return 0
+}
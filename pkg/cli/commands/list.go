@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func List(app *gn.GN, args []string) int {
+	// gn list
+	var filter gn.NoteFilter
+	listCmd := flag.NewFlagSet("list", flag.ExitOnError)
+	listCmd.StringVar(&filter.Project, "p", app.Project, "only list notes of this project")
+	listCmd.StringVar(&filter.Tag, "tag", "", "only list notes with this tag")
+	listCmd.BoolVar(&filter.Archived, "archived", false, "include archived notes")
+	listCmd.Usage = func() {
+		fmt.Println("Lists notes and their tags.")
+		listCmd.PrintDefaults()
+	}
+
+	if err := listCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing list command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	notes, err := app.Notes(filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing notes: %s\n", err.Error())
+		return 1
+	}
+
+	for _, n := range notes {
+		line := n.String()
+		if n.Archived {
+			line += " (archived)"
+		}
+		if len(n.Tags) > 0 {
+			line += "\t#" + strings.Join(n.Tags, " #")
+		}
+		fmt.Println(line)
+	}
+
+	return 0
+}
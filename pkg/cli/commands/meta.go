@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Meta(app *gn.GN, args []string) int {
+	// gn meta [get <key> | set <key> <value>]
+	metaCmd := flag.NewFlagSet("meta", flag.ExitOnError)
+	metaCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	metaCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note")
+	metaCmd.Usage = func() {
+		fmt.Println("Prints or changes the frontmatter of the note. Usage: gn meta [flags] [get <key> | set <key> <value>]")
+		metaCmd.PrintDefaults()
+	}
+
+	if err := metaCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing meta command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkMetaParams(app, metaCmd.Args()); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	switch metaCmd.Arg(0) {
+	case "get":
+		value, err := app.GetMeta(metaCmd.Arg(1))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading field: %s\n", err.Error())
+			return 1
+		}
+		fmt.Println(value)
+	case "set":
+		value := strings.Join(metaCmd.Args()[2:], " ")
+		if err := app.SetMeta(metaCmd.Arg(1), value); err != nil {
+			fmt.Fprintf(os.Stderr, "error setting field: %s\n", err.Error())
+			return 1
+		}
+	default:
+		fm, _, err := app.ReadNoteParts()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading note: %s\n", err.Error())
+			return 1
+		}
+		for _, key := range fm.Keys() {
+			value, _ := fm.Get(key)
+			fmt.Printf("%s: %s\n", key, value)
+		}
+	}
+
+	return 0
+}
+
+func checkMetaParams(app *gn.GN, args []string) error {
+	if err := checkPrintParams(app); err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+	switch args[0] {
+	case "get":
+		if len(args) != 2 {
+			return errflags.New("usage: gn meta get <key>", errflags.BadParameter)
+		}
+	case "set":
+		if len(args) < 3 {
+			return errflags.New("usage: gn meta set <key> <value>", errflags.BadParameter)
+		}
+	default:
+		return errflags.New("unknown meta command "+args[0], errflags.BadParameter)
+	}
+
+	return nil
+}
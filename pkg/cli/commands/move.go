@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+// transferFlags holds the flags shared by mv and cp
+type transferFlags struct {
+	overwrite bool
+	append    bool
+	merge     bool
+}
+
+func Move(app *gn.GN, args []string) int {
+	// gn mv
+	return transfer("mv", "Moves a note to another project/branch and commits the change.", app.Move, args)
+}
+
+func Copy(app *gn.GN, args []string) int {
+	// gn cp
+	return transfer("cp", "Copies a note to another project/branch and commits the change.", app.Copy, args)
+}
+
+func transfer(name string, description string, run func(src gn.NoteRef, dst gn.NoteRef, mode gn.ConflictMode) error, args []string) int {
+	var f transferFlags
+	cmd := flag.NewFlagSet(name, flag.ExitOnError)
+	cmd.BoolVar(&f.overwrite, "overwrite", false, "overwrite the destination note if it exists")
+	cmd.BoolVar(&f.append, "append", false, "append to the destination note if it exists")
+	cmd.BoolVar(&f.merge, "merge", false, "append only the lines missing from the destination note if it exists")
+	cmd.Usage = func() {
+		fmt.Printf("%s Example: gn %s [flags] project/branch project/other-branch\n", description, name)
+		cmd.PrintDefaults()
+	}
+
+	if err := cmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing %s command arguments: %s\n", name, err.Error())
+		return 1
+	}
+
+	mode, err := checkTransferParams(f, cmd.Args())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	src, err := gn.ParseNoteRef(cmd.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid source: %s\n", err.Error())
+		return 1
+	}
+	dst, err := gn.ParseNoteRef(cmd.Arg(1))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid destination: %s\n", err.Error())
+		return 1
+	}
+
+	if err := run(src, dst, mode); err != nil {
+		fmt.Fprintf(os.Stderr, "error running %s: %s\n", name, err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+// checkTransferParams validates the mv and cp arguments and
+// returns the conflict mode selected by the flags
+func checkTransferParams(f transferFlags, args []string) (gn.ConflictMode, error) {
+	if len(args) != 2 {
+		return gn.ConflictFail, errflags.New("expected a source and a destination", errflags.BadParameter)
+	}
+
+	mode := gn.ConflictFail
+	selected := 0
+	if f.overwrite {
+		mode = gn.ConflictOverwrite
+		selected++
+	}
+	if f.append {
+		mode = gn.ConflictAppend
+		selected++
+	}
+	if f.merge {
+		mode = gn.ConflictMerge
+		selected++
+	}
+	if selected > 1 {
+		return gn.ConflictFail, errflags.New("only one of -overwrite, -append and -merge can be used", errflags.BadParameter)
+	}
+
+	return mode, nil
+}
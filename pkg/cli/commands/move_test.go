@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckTransferParams(t *testing.T) {
+	tt := []struct {
+		name      string
+		flags     transferFlags
+		args      []string
+		expected  gn.ConflictMode
+		expectErr bool
+	}{
+		{name: "it fails on conflict by default", args: []string{"a/b", "a/c"}, expected: gn.ConflictFail},
+		{name: "it accepts overwrite", flags: transferFlags{overwrite: true}, args: []string{"a/b", "a/c"}, expected: gn.ConflictOverwrite},
+		{name: "it accepts merge", flags: transferFlags{merge: true}, args: []string{"a/b", "a/c"}, expected: gn.ConflictMerge},
+		{name: "it requires a destination", args: []string{"a/b"}, expectErr: true},
+		{name: "it does not accept more than one mode", flags: transferFlags{overwrite: true, append: true}, args: []string{"a/b", "a/c"}, expectErr: true},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			mode, err := checkTransferParams(tc.flags, tc.args)
+			if tc.expectErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, mode)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Outline(app *gn.GN, args []string) int {
+	// gn outline
+	var filter gn.NoteFilter
+	var depth int
+	var format string
+	outlineCmd := flag.NewFlagSet("outline", flag.ExitOnError)
+	outlineCmd.StringVar(&filter.Project, "p", app.Project, "only include notes of this project")
+	outlineCmd.StringVar(&filter.Tag, "tag", "", "only include notes with this tag")
+	outlineCmd.BoolVar(&filter.Archived, "archived", false, "include archived notes")
+	outlineCmd.IntVar(&depth, "depth", 0, "only include headings up to this level, 0 for all")
+	outlineCmd.StringVar(&format, "format", "text", "output format: text or json")
+	outlineCmd.Usage = func() {
+		fmt.Println("Prints the outline of the Markdown headings of the notes.")
+		outlineCmd.PrintDefaults()
+	}
+
+	if err := outlineCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing outline command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkFormat(format, "text", "json"); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	outlines, err := app.Outline(filter, depth)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading outline: %s\n", err.Error())
+		return 1
+	}
+
+	if format == "json" {
+		if err := printJSON(outlines); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing outline: %s\n", err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	project := ""
+	for _, o := range outlines {
+		if o.Project != project {
+			project = o.Project
+			fmt.Println(project)
+		}
+		branch := o.Branch
+		if o.Archived {
+			branch += " (archived)"
+		}
+		fmt.Printf("  %s\n", branch)
+		printHeadings(o.Headings, 2)
+	}
+
+	return 0
+}
+
+// printHeadings prints headings and their children, indented by indent levels
+func printHeadings(headings []gn.Heading, indent int) {
+	for _, h := range headings {
+		fmt.Printf("%s%s\n", strings.Repeat("  ", indent), h.Text)
+		printHeadings(h.Children, indent+1)
+	}
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Pin(app *gn.GN, args []string) int {
+	return pin(app, args, "pin", app.Pin)
+}
+
+func Unpin(app *gn.GN, args []string) int {
+	return pin(app, args, "unpin", app.Unpin)
+}
+
+// pin runs the pin and unpin commands, which only differ in the action
+func pin(app *gn.GN, args []string, name string, action func(gn.NoteRef) error) int {
+	// gn pin [project/branch]
+	pinCmd := flag.NewFlagSet(name, flag.ExitOnError)
+	pinCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	pinCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note")
+	pinCmd.Usage = func() {
+		fmt.Printf("Usage: gn %s [project/branch]. Without a reference, the current note is used.\n", name)
+		pinCmd.PrintDefaults()
+	}
+
+	if err := pinCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing %s command arguments: %s\n", name, err.Error())
+		return 1
+	}
+	if pinCmd.NArg() > 1 {
+		pinCmd.Usage()
+		return 1
+	}
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	ref, err := noteRefArg(app, pinCmd.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	if err := action(ref); err != nil {
+		fmt.Fprintf(os.Stderr, "error running %s: %s\n", name, err.Error())
+		return 1
+	}
+
+	return 0
+}
+
+// noteRefArg parses arg as a project/branch reference. If arg is empty,
+// it returns the note of the selected project and branch or of the working directory
+func noteRefArg(app *gn.GN, arg string) (gn.NoteRef, error) {
+	if arg != "" {
+		return gn.ParseNoteRef(arg)
+	}
+	return app.CurrentNote()
+}
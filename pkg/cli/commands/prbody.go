@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func PRBody(app *gn.GN, args []string) int {
+	// gn pr-body
+	var output string
+	prCmd := flag.NewFlagSet("pr-body", flag.ExitOnError)
+	prCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	prCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note and of the pull request")
+	prCmd.StringVar(&output, "o", "", "write the description into this file instead of stdout")
+	prCmd.Usage = func() {
+		fmt.Println("Renders the note into a pull request description (see pr-template in the config file). Example: gn pr-body | gh pr create --body-file -")
+		prCmd.PrintDefaults()
+	}
+
+	if err := prCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing pr-body command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	body, err := app.PRBody()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error rendering pull request description: %s\n", err.Error())
+		return 1
+	}
+
+	if output == "" {
+		fmt.Print(body)
+		return 0
+	}
+	if err := os.WriteFile(output, []byte(body), 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing pull request description: %s\n", err.Error())
+		return 1
+	}
+	return 0
+}
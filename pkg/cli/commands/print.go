@@ -12,7 +12,10 @@ import (
 func Print(app *gn.GN, args []string) int {
 	// gn print
 	// prints the notes into stdout
+	var archived, meta bool
 	printCmd := flag.NewFlagSet("print", flag.ExitOnError)
+	printCmd.BoolVar(&archived, "archived", false, "print an archived note")
+	printCmd.BoolVar(&meta, "meta", false, "also print the frontmatter of the note")
 	printCmd.StringVar(&app.Project, "p", app.Project, "project to edit notes")
 	printCmd.StringVar(&app.Branch, "b", app.Branch, "branch to edit notes")
 	printCmd.Usage = func() {
@@ -29,7 +32,20 @@ func Print(app *gn.GN, args []string) int {
 		return 1
 	}
 
-	_, err := fmt.Println(app.ReadNote())
+	if archived {
+		return printArchived(app)
+	}
+
+	_, body, err := app.ReadNoteParts()
+	if meta {
+		body, err = app.ReadNote()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading note: %s\n", err.Error())
+		return 1
+	}
+
+	_, err = fmt.Println(body)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error printing note content %s", err.Error())
 		return 1
@@ -37,6 +53,22 @@ func Print(app *gn.GN, args []string) int {
 	return 0
 }
 
+func printArchived(app *gn.GN) int {
+	if app.Project == "" || app.Branch == "" {
+		fmt.Fprintf(os.Stderr, "error validating parameters: project and branch are necessary to print an archived note\n")
+		return 1
+	}
+
+	content, err := app.ReadArchivedNote(gn.NoteRef{Project: app.Project, Branch: app.Branch})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading archived note: %s\n", err.Error())
+		return 1
+	}
+
+	fmt.Println(content)
+	return 0
+}
+
 func checkPrintParams(app *gn.GN) error {
 	if app.Project != "" && app.Branch == "" {
 		return errflags.New("branch is necessary when specifying a project", errflags.BadParameter)
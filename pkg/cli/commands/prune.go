@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Prune(app *gn.GN, args []string) int {
+	// gn prune
+	var archive, del bool
+	pruneCmd := flag.NewFlagSet("prune", flag.ExitOnError)
+	pruneCmd.BoolVar(&archive, "archive", false, "move the orphan notes into the archive")
+	pruneCmd.BoolVar(&del, "delete", false, "move the orphan notes into the trash")
+	pruneCmd.Usage = func() {
+		fmt.Println("Lists notes of the current project whose branch no longer exists. Nothing is changed unless -archive or -delete is given.")
+		pruneCmd.PrintDefaults()
+	}
+
+	if err := pruneCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing prune command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	action, err := checkPruneParams(archive, del)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	orphans, err := app.Prune(action)
+	for _, n := range orphans {
+		switch action {
+		case gn.PruneArchive:
+			fmt.Printf("archived %s\n", n)
+		case gn.PruneDelete:
+			fmt.Printf("moved %s to trash\n", n)
+		default:
+			fmt.Println(n)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error pruning notes: %s\n", err.Error())
+		return 1
+	}
+
+	if action == gn.PruneDryRun && len(orphans) > 0 {
+		fmt.Println("run again with -archive or -delete to remove these notes")
+	}
+
+	return 0
+}
+
+func checkPruneParams(archive bool, del bool) (gn.PruneAction, error) {
+	switch {
+	case archive && del:
+		return gn.PruneDryRun, errflags.New("-archive and -delete can't be used together", errflags.BadParameter)
+	case archive:
+		return gn.PruneArchive, nil
+	case del:
+		return gn.PruneDelete, nil
+	}
+
+	return gn.PruneDryRun, nil
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Refs(app *gn.GN, args []string) int {
+	if len(args) < 3 {
+		fmt.Println("Checks code references (path:line) of the notes. Usage: gn refs check | gn refs show <ref>")
+		return 1
+	}
+
+	switch args[2] {
+	case "check":
+		return refsCheck(app, args[3:])
+	case "show":
+		return refsShow(app, args[3:])
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown refs command %s\n", args[2])
+	return 1
+}
+
+func refsCheck(app *gn.GN, args []string) int {
+	// gn refs check
+	var noPin, yes bool
+	checkCmd := flag.NewFlagSet("refs check", flag.ExitOnError)
+	checkCmd.StringVar(&app.Project, "p", app.Project, "project whose notes are checked")
+	checkCmd.BoolVar(&noPin, "n", false, "only check, do not offer to pin references")
+	checkCmd.BoolVar(&yes, "y", false, "pin references to HEAD without asking")
+	checkCmd.Usage = func() {
+		fmt.Println("Checks that the code references (path:line) of the project notes exist at HEAD and offers to pin them to it (path@commit:line).")
+		checkCmd.PrintDefaults()
+	}
+
+	if err := checkCmd.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing refs check arguments: %s\n", err.Error())
+		return 1
+	}
+
+	refs, err := app.CheckRefs()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error checking references: %s\n", err.Error())
+		return 1
+	}
+
+	broken, pinnable := 0, 0
+	for _, c := range refs {
+		fmt.Printf("%s:%d\t%s\t%s\n", c.Note, c.NoteLine, c, c.Status)
+		if c.Status != gn.RefOK {
+			broken++
+		} else if c.Commit == "" {
+			pinnable++
+		}
+	}
+
+	if pinnable > 0 && !noPin {
+		if !yes {
+			fmt.Printf("Pin %d references to HEAD? [y/N]: ", pinnable)
+			reader := bufio.NewReader(os.Stdin)
+			answer, _ := reader.ReadString('\n')
+			yes = strings.ToLower(strings.TrimSpace(answer)) == "y"
+		}
+		if yes {
+			pinned, err := app.PinRefs(refs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error pinning references: %s\n", err.Error())
+				return 1
+			}
+			fmt.Printf("pinned %d references\n", len(pinned))
+		}
+	}
+
+	if broken > 0 {
+		fmt.Fprintf(os.Stderr, "%d broken references\n", broken)
+		return 1
+	}
+	return 0
+}
+
+func refsShow(app *gn.GN, args []string) int {
+	// gn refs show <ref>
+	var context int
+	showCmd := flag.NewFlagSet("refs show", flag.ExitOnError)
+	showCmd.IntVar(&context, "c", 3, "lines of context around the referenced line")
+	showCmd.Usage = func() {
+		fmt.Println("Prints the code a reference points to. Example: gn refs show pkg/gn/gn.go@1a2b3c4d5e6f:142")
+		showCmd.PrintDefaults()
+	}
+
+	if err := showCmd.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing refs show arguments: %s\n", err.Error())
+		return 1
+	}
+	if showCmd.NArg() != 1 {
+		showCmd.Usage()
+		return 1
+	}
+
+	c, err := gn.ParseCodeRef(showCmd.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	lines, start, err := app.ReadCodeRef(c, context)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading reference: %s\n", err.Error())
+		return 1
+	}
+	for i, line := range lines {
+		marker := " "
+		if start+i == c.Line {
+			marker = ">"
+		}
+		fmt.Printf("%s%5d  %s\n", marker, start+i, line)
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Restore(app *gn.GN, args []string) int {
+	// gn restore <id>
+	restoreCmd := flag.NewFlagSet("restore", flag.ExitOnError)
+	restoreCmd.Usage = func() {
+		fmt.Println("Restores a deleted note. Example: gn restore <id>. Run 'gn trash list' to see the ids.")
+	}
+
+	if err := restoreCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing restore command arguments: %s\n", err.Error())
+		return 1
+	}
+	if restoreCmd.NArg() != 1 {
+		restoreCmd.Usage()
+		return 1
+	}
+
+	item, err := app.Restore(restoreCmd.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error restoring note: %s\n", err.Error())
+		return 1
+	}
+	fmt.Printf("restored %s\n", item.Ref())
+
+	return 0
+}
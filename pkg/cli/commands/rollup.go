@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Rollup(app *gn.GN, args []string) int {
+	// gn rollup
+	var show bool
+	rollupCmd := flag.NewFlagSet("rollup", flag.ExitOnError)
+	rollupCmd.BoolVar(&show, "print", false, "print the project changelog after rolling up")
+	rollupCmd.Usage = func() {
+		fmt.Println("Adds the sections of merged branch notes (see rollup-sections in the config file) to the current project changelog.")
+		rollupCmd.PrintDefaults()
+	}
+
+	if err := rollupCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing rollup command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	rolled, err := app.Rollup()
+	for _, m := range rolled {
+		fmt.Printf("rolled up %s\n", m.Ref())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error rolling up notes: %s\n", err.Error())
+		return 1
+	}
+
+	if show {
+		content, err := app.ReadChangelog()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading changelog: %s\n", err.Error())
+			return 1
+		}
+		fmt.Println(content)
+	}
+
+	return 0
+}
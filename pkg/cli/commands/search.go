@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Search(app *gn.GN, args []string) int {
+	// gn search <query>
+	var filter gn.NoteFilter
+	searchCmd := flag.NewFlagSet("search", flag.ExitOnError)
+	searchCmd.StringVar(&filter.Project, "p", app.Project, "only search notes of this project")
+	searchCmd.StringVar(&filter.Tag, "tag", "", "only search notes with this tag")
+	searchCmd.BoolVar(&filter.Archived, "archived", false, "also search archived notes")
+	searchCmd.Usage = func() {
+		fmt.Println("Searches notes for a text, case insensitive. Example: gn search -tag perf cache")
+		searchCmd.PrintDefaults()
+	}
+
+	if err := searchCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing search command arguments: %s\n", err.Error())
+		return 1
+	}
+	if searchCmd.NArg() == 0 {
+		searchCmd.Usage()
+		return 1
+	}
+
+	results, err := app.Search(strings.Join(searchCmd.Args(), " "), filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error searching notes: %s\n", err.Error())
+		return 1
+	}
+
+	for _, r := range results {
+		ref := r.Ref()
+		if r.Archived {
+			ref += " (archived)"
+		}
+		fmt.Printf("%s\t%s\n", ref, r.Text)
+	}
+
+	return 0
+}
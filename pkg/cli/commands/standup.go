@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Standup(app *gn.GN, args []string) int {
+	// gn standup
+	var filter gn.NoteFilter
+	var since, until, format string
+	standupCmd := flag.NewFlagSet("standup", flag.ExitOnError)
+	standupCmd.StringVar(&filter.Project, "p", app.Project, "only include notes of this project")
+	standupCmd.StringVar(&since, "since", "yesterday", "start of the report (2006-01-02, yesterday or 30d)")
+	standupCmd.StringVar(&until, "until", "", "end of the report, now if not set")
+	standupCmd.StringVar(&format, "format", "text", "output format: text or markdown")
+	standupCmd.Usage = func() {
+		fmt.Println("Prints the lines added to the notes and the checklist items ticked or reopened, from the commits of the notes repository.")
+		standupCmd.PrintDefaults()
+	}
+
+	if err := standupCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing standup command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkFormat(format, "text", "markdown"); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+	from, to, err := parseTimeRange(since, until, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	standup, err := app.Standup(filter, from, to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading notes history: %s\n", err.Error())
+		return 1
+	}
+
+	if format == "markdown" {
+		err = standup.WriteMarkdown(os.Stdout)
+	} else {
+		err = standup.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error writing standup: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Stats(app *gn.GN, args []string) int {
+	// gn stats
+	var project, since, until, format string
+	var top int
+	statsCmd := flag.NewFlagSet("stats", flag.ExitOnError)
+	statsCmd.StringVar(&project, "p", app.Project, "only include notes of this project")
+	statsCmd.StringVar(&since, "since", "84d", "start of the activity range (2006-01-02, yesterday or 30d)")
+	statsCmd.StringVar(&until, "until", "", "end of the activity range, now if not set")
+	statsCmd.IntVar(&top, "top", 10, "number of most active branches to print")
+	statsCmd.StringVar(&format, "format", "text", "output format: text or json")
+	statsCmd.Usage = func() {
+		fmt.Println("Prints note and word counts per project, the most active branches and a heatmap of the notes repository commits.")
+		statsCmd.PrintDefaults()
+	}
+
+	if err := statsCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing stats command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkFormat(format, "text", "json"); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+	from, to, err := parseTimeRange(since, until, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	stats, err := app.Stats(project, from, to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading stats: %s\n", err.Error())
+		return 1
+	}
+
+	if format == "json" {
+		if err := printJSON(stats); err != nil {
+			fmt.Fprintf(os.Stderr, "error writing stats: %s\n", err.Error())
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Println("Projects")
+	for _, p := range stats.Projects {
+		fmt.Printf("  %s\t%d notes\t%d words\n", p.Project, p.Notes, p.Words)
+	}
+
+	fmt.Printf("\nMost active branches since %s\n", stats.Since.Format(time.DateOnly))
+	for i, b := range stats.Branches {
+		if i == top {
+			break
+		}
+		fmt.Printf("  %s/%s\t%d commits\t+%d -%d\n", b.Project, b.Branch, b.Commits, b.Additions, b.Deletions)
+	}
+
+	fmt.Println("\nActivity")
+	if err := stats.WriteHeatmap(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "error writing heatmap: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
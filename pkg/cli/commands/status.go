@@ -0,0 +1,62 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Status(app *gn.GN, args []string) int {
+	// gn status [set <status> | log]
+	statusCmd := flag.NewFlagSet("status", flag.ExitOnError)
+	statusCmd.StringVar(&app.Project, "p", app.Project, "project of the note")
+	statusCmd.StringVar(&app.Branch, "b", app.Branch, "branch of the note")
+	statusCmd.Usage = func() {
+		fmt.Println("Prints or changes the status of the note. Usage: gn status [flags] [set <status> | log]")
+		statusCmd.PrintDefaults()
+	}
+
+	if err := statusCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing status command arguments: %s\n", err.Error())
+		return 1
+	}
+	if err := checkPrintParams(app); err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	switch statusCmd.Arg(0) {
+	case "":
+		status, err := app.Status()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading status: %s\n", err.Error())
+			return 1
+		}
+		fmt.Println(status)
+	case "set":
+		if statusCmd.NArg() != 2 {
+			statusCmd.Usage()
+			return 1
+		}
+		if err := app.SetStatus(statusCmd.Arg(1)); err != nil {
+			fmt.Fprintf(os.Stderr, "error setting status: %s\n", err.Error())
+			return 1
+		}
+	case "log":
+		changes, err := app.StatusHistory()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error reading status history: %s\n", err.Error())
+			return 1
+		}
+		for _, c := range changes {
+			fmt.Printf("%s\t%s\t%s\n", c.When.Format("2006-01-02 15:04"), c.Commit.String()[:7], c.Status)
+		}
+	default:
+		statusCmd.Usage()
+		return 1
+	}
+
+	return 0
+}
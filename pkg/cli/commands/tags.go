@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Tags(app *gn.GN, args []string) int {
+	// gn tags
+	var filter gn.NoteFilter
+	tagsCmd := flag.NewFlagSet("tags", flag.ExitOnError)
+	tagsCmd.StringVar(&filter.Project, "p", app.Project, "only list tags of this project")
+	tagsCmd.BoolVar(&filter.Archived, "archived", false, "include archived notes")
+	tagsCmd.Usage = func() {
+		fmt.Println("Lists #tags and frontmatter tags with the number of notes using them.")
+		tagsCmd.PrintDefaults()
+	}
+
+	if err := tagsCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing tags command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	tags, err := app.Tags(filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing tags: %s\n", err.Error())
+		return 1
+	}
+
+	for _, t := range tags {
+		fmt.Printf("%d\t#%s\n", t.Count, t.Tag)
+	}
+
+	return 0
+}
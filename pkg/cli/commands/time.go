@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Time(app *gn.GN, args []string) int {
+	// gn time
+	var project, since, until string
+	var reflog bool
+	timeCmd := flag.NewFlagSet("time", flag.ExitOnError)
+	timeCmd.StringVar(&project, "p", app.Project, "only report branches of this project")
+	timeCmd.StringVar(&since, "since", "7d", "start of the report (2006-01-02, yesterday or 30d)")
+	timeCmd.StringVar(&until, "until", "", "end of the report, now if not set")
+	timeCmd.BoolVar(&reflog, "reflog", false, "first add the checkouts of the working repository reflog to the time log")
+	timeCmd.Usage = func() {
+		fmt.Println("Reports the time spent on each branch, from the logged edit sessions and, with -reflog, branch checkouts.")
+		timeCmd.PrintDefaults()
+	}
+
+	if err := timeCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing time command arguments: %s\n", err.Error())
+		return 1
+	}
+	from, to, err := parseTimeRange(since, until, time.Now())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	if reflog {
+		if _, err := app.ImportCheckouts(); err != nil {
+			fmt.Fprintf(os.Stderr, "error reading checkouts: %s\n", err.Error())
+			return 1
+		}
+	}
+
+	entries, err := app.TimeSpent(project, from, to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error reading time log: %s\n", err.Error())
+		return 1
+	}
+
+	var edit, checkout time.Duration
+	for _, e := range entries {
+		fmt.Printf("%s\tedit %s\tcheckout %s\n", e, formatDuration(e.Edit), formatDuration(e.Checkout))
+		edit += e.Edit
+		checkout += e.Checkout
+	}
+	if len(entries) > 1 {
+		fmt.Printf("total\tedit %s\tcheckout %s\n", formatDuration(edit), formatDuration(checkout))
+	}
+
+	return 0
+}
+
+// formatDuration formats d rounded to the minute, e.g. 1h20m
+func formatDuration(d time.Duration) string {
+	s := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	if s == "" {
+		return "0m"
+	}
+	return s
+}
@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tt := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{input: 0, expected: "0m"},
+		{input: 20 * time.Second, expected: "0m"},
+		{input: 45 * time.Minute, expected: "45m"},
+		{input: 80*time.Minute + 10*time.Second, expected: "1h20m"},
+		{input: 2 * time.Hour, expected: "2h"},
+	}
+	for _, tc := range tt {
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, formatDuration(tc.input))
+		})
+	}
+}
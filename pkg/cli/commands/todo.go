@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Todo(app *gn.GN, args []string) int {
+	// gn todo done <ref>
+	if len(args) > 2 && args[2] == "done" {
+		return todoDone(app, args[3:])
+	}
+
+	// gn todo
+	var filter gn.NoteFilter
+	todoCmd := flag.NewFlagSet("todo", flag.ExitOnError)
+	todoCmd.StringVar(&filter.Project, "p", app.Project, "only list todos of this project")
+	todoCmd.StringVar(&filter.Tag, "tag", "", "only list todos of notes with this tag")
+	todoCmd.Usage = func() {
+		fmt.Println("Lists open checklist items (- [ ]) of all notes. Run 'gn todo done project/branch:line' to tick one.")
+		todoCmd.PrintDefaults()
+	}
+
+	if err := todoCmd.Parse(args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing todo command arguments: %s\n", err.Error())
+		return 1
+	}
+
+	todos, err := app.Todos(filter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing todos: %s\n", err.Error())
+		return 1
+	}
+
+	var current gn.NoteRef
+	for _, t := range todos {
+		if t.Note != current {
+			current = t.Note
+			fmt.Println(current)
+		}
+		fmt.Printf("  %s\t%s\n", t.Ref(), t.Text)
+	}
+
+	return 0
+}
+
+func todoDone(app *gn.GN, args []string) int {
+	doneCmd := flag.NewFlagSet("todo done", flag.ExitOnError)
+	doneCmd.Usage = func() {
+		fmt.Println("Ticks a checklist item and commits the note. Example: gn todo done billing/main:12")
+	}
+
+	if err := doneCmd.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing todo done arguments: %s\n", err.Error())
+		return 1
+	}
+	if doneCmd.NArg() != 1 {
+		doneCmd.Usage()
+		return 1
+	}
+
+	ref, line, err := gn.ParseTodoRef(doneCmd.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+		return 1
+	}
+
+	item, err := app.CompleteTodo(ref, line)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error completing todo: %s\n", err.Error())
+		return 1
+	}
+	fmt.Printf("done: %s\n", item.Text)
+
+	return 0
+}
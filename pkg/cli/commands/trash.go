@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/gn"
+)
+
+func Trash(app *gn.GN, args []string) int {
+	// gn trash <list|empty>
+	usage := func() {
+		fmt.Println("Manages deleted notes. Usage: gn trash <list|empty> [flags]")
+	}
+
+	if len(args) < 3 {
+		usage()
+		return 1
+	}
+
+	switch args[2] {
+	case "list":
+		return trashList(app)
+	case "empty":
+		return trashEmpty(app, args[3:])
+	case "-h", "--help", "help":
+		usage()
+		return 0
+	default:
+		usage()
+		return 1
+	}
+}
+
+func trashList(app *gn.GN) int {
+	items, err := app.ListTrash()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error listing trash: %s\n", err.Error())
+		return 1
+	}
+
+	for _, item := range items {
+		fmt.Printf("%s\t%s\t%s\n", item.ID, item.Deleted.Local().Format(time.DateTime), item.Ref())
+	}
+
+	return 0
+}
+
+func trashEmpty(app *gn.GN, args []string) int {
+	var olderThan string
+	emptyCmd := flag.NewFlagSet("trash empty", flag.ExitOnError)
+	emptyCmd.StringVar(&olderThan, "older-than", "", "only remove notes deleted longer ago than this (e.g. 30d, 12h)")
+	emptyCmd.Usage = func() {
+		fmt.Println("Permanently removes notes from the trash.")
+		emptyCmd.PrintDefaults()
+	}
+
+	if err := emptyCmd.Parse(args); err != nil {
+		fmt.Fprintf(os.Stderr, "error parsing trash empty arguments: %s\n", err.Error())
+		return 1
+	}
+
+	var age time.Duration
+	if olderThan != "" {
+		var err error
+		age, err = parseDuration(olderThan)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error validating parameters: %s\n", err.Error())
+			return 1
+		}
+	}
+
+	removed, err := app.EmptyTrash(age)
+	for _, item := range removed {
+		fmt.Printf("removed %s (%s)\n", item.ID, item.Ref())
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error emptying trash: %s\n", err.Error())
+		return 1
+	}
+
+	return 0
+}
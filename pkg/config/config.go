@@ -53,7 +53,43 @@ func ReadConfigFile(gn *gn.GN, path string, fileName string) error {
 			if parseInput(s[1]) == "true" {
 				gn.AlwaysCommit = true
 			}
-
+		case "frontmatter":
+			if parseInput(s[1]) == "true" {
+				gn.Frontmatter = true
+			}
+		case "track-time":
+			if parseInput(s[1]) == "true" {
+				gn.TrackTime = true
+			}
+		case "templates":
+			gn.TemplatesPath = parseInput(s[1])
+		case "default-branch":
+			gn.DefaultBranch = parseInput(s[1])
+		case "archive-merged":
+			if parseInput(s[1]) == "true" {
+				gn.ArchiveMergedAuto = true
+			}
+		case "commit-msg-section":
+			gn.CommitMsgSection = parseInput(s[1])
+		case "commit-msg-trailer":
+			if parseInput(s[1]) == "true" {
+				gn.CommitMsgTrailer = true
+			}
+		case "pr-template":
+			gn.PRTemplate = parseInput(s[1])
+		case "branch-desc-section":
+			gn.BranchDescSection = parseInput(s[1])
+		case "statuses":
+			gn.Statuses = parseList(s[1])
+		case "rollup-sections":
+			gn.RollupSections = parseList(s[1])
+		case "prune-keep":
+			setPruneKeep(gn, "", parseList(s[1]))
+		default:
+			// prune-keep.<project> holds the branches to keep for a single project
+			if project, ok := strings.CutPrefix(s[0], "prune-keep."); ok {
+				setPruneKeep(gn, project, parseList(s[1]))
+			}
 		}
 	}
 	if err := scanner.Err(); err != nil {
@@ -71,6 +107,25 @@ func parseInput(i string) string {
 	return os.ExpandEnv(s)
 }
 
+// parseList parses a comma separated list of values
+func parseList(i string) []string {
+	values := []string{}
+	for _, v := range strings.Split(parseInput(i), ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func setPruneKeep(gn *gn.GN, project string, branches []string) {
+	if gn.PruneKeep == nil {
+		gn.PruneKeep = map[string][]string{}
+	}
+	gn.PruneKeep[project] = branches
+}
+
 func createConfigFile(configPath string, fileName string) error {
 	if err := os.MkdirAll(configPath, os.ModeDir|0700); err != nil {
 		return err
@@ -29,6 +29,24 @@ func TestReadConfigFile(t *testing.T) {
 	assert.Equal(t, "vim", gn.Editor)
 	assert.Equal(t, os.ExpandEnv("$HOME/gitnotes"), gn.NotesPath)
 	assert.Equal(t, false, gn.AlwaysCommit)
+	assert.Equal(t, false, gn.TrackTime)
+	assert.Equal(t, map[string][]string{"": {"main", "master"}}, gn.PruneKeep)
+}
+
+func TestReadConfigFilePruneKeep(t *testing.T) {
+	gn := gn.GN{}
+	testDir := t.TempDir()
+	fileName := "test.conf"
+	content := "prune-keep=main\nprune-keep.billing=develop, release/* # comment\n"
+	err := os.WriteFile(fmt.Sprintf("%s/%s", testDir, fileName), []byte(content), 0644)
+	assert.NoError(t, err)
+
+	err = ReadConfigFile(&gn, testDir, fileName)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"":        {"main"},
+		"billing": {"develop", "release/*"},
+	}, gn.PruneKeep)
 }
 
 func TestParseInput(t *testing.T) {
@@ -0,0 +1,207 @@
+package gn
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// dateTagRegex matches date annotations, like @due(2026-11-02)
+// or @remind(2026-11-02 09:30)
+var dateTagRegex = regexp.MustCompile(`@(due|remind)\(([^)]*)\)`)
+
+// agendaDateLayouts are the accepted formats of annotation dates
+var agendaDateLayouts = []string{"2006-01-02 15:04", "2006-01-02T15:04", "2006-01-02"}
+
+// AgendaItem is a note line annotated with a date
+type AgendaItem struct {
+	Note NoteRef
+	// Line is the 1-based line number of the annotation in the note
+	Line int
+	// Kind is due or remind
+	Kind string
+	Date time.Time
+	// AllDay is true when the annotation has no time
+	AllDay bool
+	// Text is the line without the annotation
+	Text string
+}
+
+// Ref returns the reference of the item, in the form project/branch:line
+func (a AgendaItem) Ref() string {
+	return fmt.Sprintf("%s:%d", a.Note, a.Line)
+}
+
+// Agenda returns the date annotated lines of the notes selected by filter,
+// sorted by date. Ticked checklist items are left out
+func (gn *GN) Agenda(filter NoteFilter) ([]AgendaItem, error) {
+	notes, err := gn.loadNotes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items := []AgendaItem{}
+	for _, n := range notes {
+		items = append(items, parseAgenda(n.NoteRef, n.Content, gn.log.Debug)...)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Date.Before(items[j].Date)
+	})
+	return items, nil
+}
+
+// parseAgenda returns the date annotations of the note content.
+// Invalid dates are reported to warn and skipped
+func parseAgenda(ref NoteRef, content string, warn func(format string, a ...any)) []AgendaItem {
+	items := []AgendaItem{}
+	for i, line := range strings.Split(content, "\n") {
+		matches := dateTagRegex.FindAllStringSubmatch(line, -1)
+		if matches == nil {
+			continue
+		}
+
+		text := line
+		if m := checkboxRegex.FindStringSubmatch(line); m != nil {
+			if m[2] != " " {
+				continue
+			}
+			text = m[4]
+		}
+		text = strings.TrimSpace(dateTagRegex.ReplaceAllString(text, ""))
+		text = strings.TrimSpace(strings.TrimLeft(text, "-*+ "))
+
+		for _, m := range matches {
+			date, allDay, err := parseAgendaDate(m[2])
+			if err != nil {
+				warn("invalid date %q in %s:%d", m[2], ref, i+1)
+				continue
+			}
+			items = append(items, AgendaItem{
+				Note:   ref,
+				Line:   i + 1,
+				Kind:   m[1],
+				Date:   date,
+				AllDay: allDay,
+				Text:   text,
+			})
+		}
+	}
+	return items
+}
+
+// parseAgendaDate parses the date of an annotation in the local time zone
+func parseAgendaDate(s string) (time.Time, bool, error) {
+	s = strings.TrimSpace(s)
+	var err error
+	for _, layout := range agendaDateLayouts {
+		var t time.Time
+		t, err = time.ParseInLocation(layout, s, time.Local)
+		if err == nil {
+			return t, layout == "2006-01-02", nil
+		}
+	}
+	return time.Time{}, false, err
+}
+
+// WriteICS writes the items as an iCalendar file into w.
+// Reminders get an alarm at their date
+func WriteICS(w io.Writer, items []AgendaItem, now time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//gitnotes//gn agenda//EN",
+		"CALSCALE:GREGORIAN",
+	}
+
+	for _, item := range items {
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+item.uid()+"@gitnotes",
+			"DTSTAMP:"+now.UTC().Format("20060102T150405Z"),
+		)
+		if item.AllDay {
+			lines = append(lines,
+				"DTSTART;VALUE=DATE:"+item.Date.Format("20060102"),
+				"DTEND;VALUE=DATE:"+item.Date.AddDate(0, 0, 1).Format("20060102"),
+			)
+		} else {
+			lines = append(lines, "DTSTART:"+item.Date.UTC().Format("20060102T150405Z"))
+		}
+		lines = append(lines,
+			"SUMMARY:"+escapeICS(fmt.Sprintf("%s: %s", item.Kind, item.Text)),
+			"DESCRIPTION:"+escapeICS(item.Ref()),
+		)
+		if item.Kind == "remind" {
+			lines = append(lines,
+				"BEGIN:VALARM",
+				"ACTION:DISPLAY",
+				"DESCRIPTION:"+escapeICS(item.Text),
+				"TRIGGER:PT0M",
+				"END:VALARM",
+			)
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, l := range lines {
+		if _, err := io.WriteString(w, foldICS(l)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// uid returns an identifier that stays the same
+// while the annotation does not change
+func (a AgendaItem) uid() string {
+	h := sha1.Sum([]byte(fmt.Sprintf("%s|%s|%s|%s", a.Note, a.Kind, a.Date.Format(time.RFC3339), a.Text)))
+	return hex.EncodeToString(h[:])
+}
+
+// escapeICS escapes text values as required by RFC 5545.
+// Carriage returns are dropped, only line feeds can be escaped
+func escapeICS(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", "")
+	return r.Replace(s)
+}
+
+// foldICS splits lines longer than 75 octets, as required by RFC 5545.
+// Continuation lines start with a space
+func foldICS(line string) string {
+	const maxLen = 75
+	if len(line) <= maxLen {
+		return line
+	}
+
+	var sb strings.Builder
+	limit := maxLen
+	for len(line) > limit {
+		// do not split multi-byte characters
+		cut := limit
+		for cut > 0 && !isRuneStart(line[cut]) {
+			cut--
+		}
+		if cut == 0 {
+			// invalid UTF-8, there is no character to keep whole
+			cut = limit
+		}
+		sb.WriteString(line[:cut])
+		sb.WriteString("\r\n ")
+		line = line[cut:]
+		// continuation lines have a leading space
+		limit = maxLen - 1
+	}
+	sb.WriteString(line)
+	return sb.String()
+}
+
+func isRuneStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,80 @@
+package gn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgenda(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "- [ ] ship refunds @due(2026-11-02)\n- [x] done @due(2026-01-01)\ncall bank @remind(2026-10-20 09:30)\nbad @due(tomorrow)\n")
+
+	items, err := gn.Agenda(NoteFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	assert.Equal(t, "remind", items[0].Kind)
+	assert.Equal(t, "call bank", items[0].Text)
+	assert.Equal(t, time.Date(2026, 10, 20, 9, 30, 0, 0, time.Local), items[0].Date)
+	assert.False(t, items[0].AllDay)
+	assert.Equal(t, "billing/main:3", items[0].Ref())
+
+	assert.Equal(t, "due", items[1].Kind)
+	assert.Equal(t, "ship refunds", items[1].Text)
+	assert.True(t, items[1].AllDay)
+}
+
+func TestWriteICS(t *testing.T) {
+	items := []AgendaItem{
+		{
+			Note:   NoteRef{Project: "billing", Branch: "main"},
+			Line:   1,
+			Kind:   "due",
+			Date:   time.Date(2026, 11, 2, 0, 0, 0, 0, time.Local),
+			AllDay: true,
+			Text:   "ship refunds, finally",
+		},
+		{
+			Note: NoteRef{Project: "billing", Branch: "main"},
+			Line: 2,
+			Kind: "remind",
+			Date: time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC),
+			Text: strings.Repeat("long ", 30),
+		},
+	}
+
+	var buf bytes.Buffer
+	err := WriteICS(&buf, items, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	ics := buf.String()
+	assert.True(t, strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(ics, "END:VCALENDAR\r\n"))
+	assert.Contains(t, ics, "DTSTART;VALUE=DATE:20261102\r\n")
+	assert.Contains(t, ics, "DTEND;VALUE=DATE:20261103\r\n")
+	assert.Contains(t, ics, "SUMMARY:due: ship refunds\\, finally\r\n")
+	assert.Contains(t, ics, "DTSTART:20261020T093000Z\r\n")
+	assert.Contains(t, ics, "BEGIN:VALARM\r\n")
+	assert.Equal(t, 2, strings.Count(ics, "BEGIN:VEVENT"))
+
+	for _, line := range strings.Split(ics, "\r\n") {
+		assert.LessOrEqual(t, len(line), 75)
+	}
+}
+
+func TestFoldICSInvalidUTF8(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("\x80", 200)
+	folded := foldICS(line)
+	for _, l := range strings.Split(folded, "\r\n") {
+		assert.LessOrEqual(t, len(l), 75)
+	}
+	assert.Equal(t, line, strings.ReplaceAll(folded, "\r\n ", ""))
+}
+
+func TestEscapeICS(t *testing.T) {
+	assert.Equal(t, `a\, b\; c\\d\ne\nf`, escapeICS("a, b; c\\d\r\ne\nf\r"))
+}
@@ -0,0 +1,298 @@
+package gn
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// archiveDir is the directory, inside the notes path, where notes
+// of branches that are no longer worked on are kept.
+// Each archived note is stored as archiveDir/<project>/<branch>/note,
+// next to a meta file describing it
+const archiveDir = ".archive"
+
+const (
+	archiveNoteFile = "note"
+	archiveMetaFile = "meta"
+)
+
+// ArchivedNote is a note that was moved into the archive
+type ArchivedNote struct {
+	Project  string
+	Branch   string
+	Archived time.Time
+	// MergeCommit is the hash of the commit that merged the branch
+	// into the default branch, if the note was archived because of it
+	MergeCommit string
+	// Merged is the date of MergeCommit
+	Merged time.Time
+}
+
+// Ref returns the reference of the note before it was archived
+func (a ArchivedNote) Ref() NoteRef {
+	return NoteRef{Project: a.Project, Branch: a.Branch}
+}
+
+// path returns the path of the archived note inside notesPath
+func (a ArchivedNote) path(notesPath string) string {
+	return filepath.Join(notesPath, archiveDir, a.Project, a.Branch, archiveNoteFile)
+}
+
+// archive moves the note of a.Project and a.Branch into the archive.
+// The merge fields of a are recorded in its metadata
+func (gn *GN) archive(a ArchivedNote) (ArchivedNote, error) {
+	ref := a.Ref()
+	notePath := ref.path(gn.NotesPath)
+	if _, err := os.Stat(notePath); err != nil {
+		if os.IsNotExist(err) {
+			return ArchivedNote{}, errflags.Flag(fmt.Errorf("note %s not found", ref), errflags.NotFound)
+		}
+		return ArchivedNote{}, err
+	}
+
+	a.Archived = time.Now()
+
+	archivePath := a.path(gn.NotesPath)
+	if _, err := os.Stat(archivePath); err == nil {
+		return ArchivedNote{}, errflags.New(fmt.Sprintf("note %s is already archived", ref), errflags.BadParameter)
+	}
+	if err := os.MkdirAll(filepath.Dir(archivePath), os.ModeDir|0700); err != nil {
+		return ArchivedNote{}, err
+	}
+
+	pairs := [][2]string{
+		{"project", a.Project},
+		{"branch", a.Branch},
+		{"archived", a.Archived.Format(time.RFC3339)},
+	}
+	if a.MergeCommit != "" {
+		pairs = append(pairs,
+			[2]string{"merge-commit", a.MergeCommit},
+			[2]string{"merged", a.Merged.Format(time.RFC3339)},
+		)
+	}
+	if err := writeKeyValues(filepath.Join(filepath.Dir(archivePath), archiveMetaFile), pairs...); err != nil {
+		return ArchivedNote{}, err
+	}
+
+	gn.log.Debug("archiving %s into %s", notePath, archivePath)
+	if err := os.Rename(notePath, archivePath); err != nil {
+		return ArchivedNote{}, err
+	}
+	content, err := os.ReadFile(archivePath)
+	if err != nil {
+		return ArchivedNote{}, err
+	}
+	if rebased := rebaseAssetLinks(content, filepath.Dir(notePath), filepath.Dir(archivePath)); !bytes.Equal(rebased, content) {
+		if err := os.WriteFile(archivePath, rebased, 0644); err != nil {
+			return ArchivedNote{}, err
+		}
+	}
+	removeEmptyParents(notePath, filepath.Join(gn.NotesPath, ref.Project))
+
+	return a, nil
+}
+
+// ArchiveMerged archives the notes of the current project whose branch
+// was merged into the default branch.
+// If dryRun is true, the notes are only returned
+func (gn *GN) ArchiveMerged(dryRun bool) ([]ArchivedNote, error) {
+	project, err := gn.findProject()
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := gn.findMergedNotes(project)
+	if err != nil || dryRun {
+		return merged, err
+	}
+
+	archived := []ArchivedNote{}
+	for _, m := range merged {
+		a, err := gn.archive(m)
+		if err != nil {
+			return archived, err
+		}
+		archived = append(archived, a)
+	}
+
+	return archived, nil
+}
+
+// findMergedNotes returns the notes of project whose branch was merged into
+// the default branch of the working repository, that is, whose tip is
+// reachable from it. The returned notes have the merge fields set.
+// Branches pointing to the same commit as the default branch are not
+// considered merged, since they usually were just created
+func (gn *GN) findMergedNotes(project string) ([]ArchivedNote, error) {
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	defaultBranch, err := gn.defaultBranch(r)
+	if err != nil {
+		return nil, err
+	}
+
+	defaultRef, err := resolveBranch(r, defaultBranch)
+	if err != nil {
+		return nil, err
+	}
+	base, err := r.CommitObject(defaultRef.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := gn.listNotes(project)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := []ArchivedNote{}
+	for _, n := range notes {
+		if n.Branch == defaultBranch {
+			continue
+		}
+
+		ref, err := resolveBranch(r, n.Branch)
+		if err != nil {
+			gn.log.Debug("skipping %s: %s", n, err.Error())
+			continue
+		}
+		if ref.Hash() == base.Hash {
+			continue
+		}
+
+		tip, err := r.CommitObject(ref.Hash())
+		if err != nil {
+			return nil, err
+		}
+		merge, err := findMergeCommit(tip, base)
+		if err != nil {
+			return nil, err
+		}
+		if merge == nil {
+			continue
+		}
+
+		merged = append(merged, ArchivedNote{
+			Project:     n.Project,
+			Branch:      n.Branch,
+			MergeCommit: merge.Hash.String(),
+			Merged:      merge.Committer.When,
+		})
+	}
+
+	return merged, nil
+}
+
+// ListArchive returns the archived notes. If project is not empty,
+// only the archived notes of that project are returned
+func (gn *GN) ListArchive(project string) ([]ArchivedNote, error) {
+	root := filepath.Join(gn.NotesPath, archiveDir, project)
+
+	archived := []ArchivedNote{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || d.Name() != archiveMetaFile {
+			return nil
+		}
+
+		a, err := readArchivedNote(path)
+		if err != nil {
+			gn.log.Debug("skipping archived note %s: %s", path, err.Error())
+			return nil
+		}
+		archived = append(archived, a)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return archived, nil
+}
+
+// readArchivedNote reads the meta file of an archived note
+func readArchivedNote(metaPath string) (ArchivedNote, error) {
+	values, err := readKeyValues(metaPath)
+	if err != nil {
+		return ArchivedNote{}, err
+	}
+
+	a := ArchivedNote{
+		Project:     values["project"],
+		Branch:      values["branch"],
+		MergeCommit: values["merge-commit"],
+	}
+	if a.Archived, err = time.Parse(time.RFC3339, values["archived"]); err != nil {
+		return ArchivedNote{}, err
+	}
+	if values["merged"] != "" {
+		if a.Merged, err = time.Parse(time.RFC3339, values["merged"]); err != nil {
+			return ArchivedNote{}, err
+		}
+	}
+
+	return a, nil
+}
+
+// ReadArchivedNote returns the content of the archived note of ref
+func (gn *GN) ReadArchivedNote(ref NoteRef) (string, error) {
+	content, err := os.ReadFile(ArchivedNote{Project: ref.Project, Branch: ref.Branch}.path(gn.NotesPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errflags.Flag(fmt.Errorf("archived note %s not found", ref), errflags.NotFound)
+		}
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// autoArchive runs ArchiveMerged if ArchiveMergedAuto is set and the
+// current working branch is the default branch.
+// Errors are only logged, since it runs after other commands
+func (gn *GN) autoArchive() {
+	if !gn.ArchiveMergedAuto || gn.Project != "" || gn.Branch != "" {
+		return
+	}
+
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return
+	}
+	branch, err := getCurrentBranch(r)
+	if err != nil {
+		return
+	}
+
+	defaultBranch, err := gn.defaultBranch(r)
+	if err != nil {
+		gn.log.Debug("auto archive: %s", err.Error())
+		return
+	}
+	if branch != defaultBranch {
+		return
+	}
+
+	archived, err := gn.ArchiveMerged(false)
+	if err != nil {
+		gn.log.Info("failed to archive merged branches notes: %s\n", err.Error())
+	}
+	for _, a := range archived {
+		gn.log.Info("archived note of merged branch %s\n", a.Ref())
+	}
+}
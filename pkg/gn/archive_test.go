@@ -0,0 +1,51 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchiveMerged(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/merged", true)
+	tr.commitFile("merged.txt", "merged\n", "Add merged")
+	tr.checkout("main", false)
+	mergeHash := tr.merge("feat/merged")
+	tr.checkout("feat/open", true)
+	tr.commitFile("open.txt", "open\n", "Add open")
+	tr.checkout("main", false)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	writeTestNote(t, gn, "billing", "main", "main")
+	writeTestNote(t, gn, "billing", "feat/merged", "merged notes")
+	writeTestNote(t, gn, "billing", "feat/open", "open notes")
+
+	archived, err := gn.ArchiveMerged(true)
+	assert.NoError(t, err)
+	assert.Len(t, archived, 1)
+	notes, err := gn.listNotes("billing")
+	assert.NoError(t, err)
+	assert.Len(t, notes, 3, "dry run should not archive notes")
+
+	archived, err = gn.ArchiveMerged(false)
+	assert.NoError(t, err)
+	assert.Len(t, archived, 1)
+	assert.Equal(t, NoteRef{Project: "billing", Branch: "feat/merged"}, archived[0].Ref())
+	assert.Equal(t, mergeHash.String(), archived[0].MergeCommit)
+
+	notes, err = gn.listNotes("billing")
+	assert.NoError(t, err)
+	assert.Equal(t, []NoteRef{{Project: "billing", Branch: "feat/open"}, {Project: "billing", Branch: "main"}}, notes)
+
+	list, err := gn.ListArchive("billing")
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, mergeHash.String(), list[0].MergeCommit)
+	assert.Equal(t, archived[0].Merged.Unix(), list[0].Merged.Unix())
+
+	content, err := gn.ReadArchivedNote(NoteRef{Project: "billing", Branch: "feat/merged"})
+	assert.NoError(t, err)
+	assert.Equal(t, "merged notes", content)
+}
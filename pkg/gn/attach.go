@@ -0,0 +1,252 @@
+package gn
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// assetsDir is the directory, inside each project directory,
+// where the files attached to the project notes are kept
+const assetsDir = ".assets"
+
+// assetHashLen is the length of the content hash prefixed to attachment names
+const assetHashLen = 12
+
+// imageExtensions are the extensions of attachments linked as images
+var imageExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".svg": true, ".webp": true,
+}
+
+// linkTargetRegex matches the targets of Markdown links and images,
+// e.g. (../.assets/1a2b3c4d5e6f-shot.png) or (<../.assets/1a2b3c4d5e6f-my shot.png>)
+var linkTargetRegex = regexp.MustCompile(`\]\((<[^<>\n]+>|[^()<>\s]+)\)`)
+
+// Attachment is a file attached to the notes of a project
+type Attachment struct {
+	Project string
+	// Name is the file name inside the assets directory, <hash>-<original name>
+	Name string
+	Size int64
+	// Notes are the notes that link to the attachment
+	Notes []NoteRef
+}
+
+// Attach copies file into the assets directory of the project of the current
+// note and appends a link to it to the note. Files with the same content are
+// stored once. The note and the attachment are commited together.
+// It returns the link added to the note
+func (gn *GN) Attach(file string) (string, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errflags.Flag(fmt.Errorf("file %s not found", file), errflags.NotFound)
+		}
+		return "", err
+	}
+
+	dir := filepath.Join(gn.NotesPath, ref.Project, assetsDir)
+	if err := os.MkdirAll(dir, os.ModeDir|0700); err != nil {
+		return "", err
+	}
+
+	name, err := findAsset(dir, content)
+	if err != nil {
+		return "", err
+	}
+	if name == "" {
+		name = assetName(content, filepath.Base(file))
+		gn.log.Debug("copying %s to %s", file, name)
+		if err := os.WriteFile(filepath.Join(dir, name), content, 0644); err != nil {
+			return "", err
+		}
+	}
+
+	notePath := ref.path(gn.NotesPath)
+	target, err := assetLinkTarget(filepath.Dir(notePath), filepath.Join(dir, name))
+	if err != nil {
+		return "", err
+	}
+	link := fmt.Sprintf("[%s](%s)", filepath.Base(file), target)
+	if imageExtensions[strings.ToLower(filepath.Ext(file))] {
+		link = "!" + link
+	}
+
+	note, err := os.ReadFile(notePath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(notePath), os.ModeDir|0700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(notePath, appendNote(note, []byte(link+"\n")), 0644); err != nil {
+		return "", err
+	}
+	if err := gn.touchNote(notePath, note); err != nil {
+		return "", err
+	}
+
+	return link, gn.commitPaths(fmt.Sprintf("Attach %s to %s", filepath.Base(file), ref),
+		filepath.Join(ref.Project, ref.Branch), filepath.Join(ref.Project, assetsDir, name))
+}
+
+// assetLinkTarget returns the target of a link to the attachment at path
+// from a note in dir
+func assetLinkTarget(dir string, path string) (string, error) {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return "", err
+	}
+	target := filepath.ToSlash(rel)
+	if strings.Contains(target, " ") {
+		target = "<" + target + ">"
+	}
+	return target, nil
+}
+
+// rebaseAssetLinks rewrites the links to attachments of content, a note in
+// fromDir, so they still point to the same files from a note in toDir.
+// Links are relative, so they break when a note changes directory
+func rebaseAssetLinks(content []byte, fromDir string, toDir string) []byte {
+	if fromDir == toDir {
+		return content
+	}
+	return linkTargetRegex.ReplaceAllFunc(content, func(link []byte) []byte {
+		target := linkTarget(link)
+		if filepath.IsAbs(target) || strings.Contains(target, "://") ||
+			!(strings.HasPrefix(target, assetsDir+"/") || strings.Contains(target, "/"+assetsDir+"/")) {
+			return link
+		}
+		rebased, err := assetLinkTarget(toDir, filepath.Join(fromDir, filepath.FromSlash(target)))
+		if err != nil {
+			return link
+		}
+		return []byte("](" + rebased + ")")
+	})
+}
+
+// linkTarget returns the target of a link matched by linkTargetRegex,
+// without the angle brackets of targets with spaces
+func linkTarget(link []byte) string {
+	return strings.TrimSuffix(strings.TrimPrefix(string(link[2:len(link)-1]), "<"), ">")
+}
+
+// linkedFiles returns the paths of the files content, a note in dir,
+// links to with relative links
+func linkedFiles(content string, dir string) []string {
+	paths := []string{}
+	for _, link := range linkTargetRegex.FindAll([]byte(content), -1) {
+		target := linkTarget(link)
+		if filepath.IsAbs(target) || strings.Contains(target, "://") {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, filepath.FromSlash(target)))
+	}
+	return paths
+}
+
+// assetName returns the name of an attachment with the given content
+func assetName(content []byte, base string) string {
+	h := sha256.Sum256(content)
+	return hex.EncodeToString(h[:])[:assetHashLen] + "-" + base
+}
+
+// findAsset returns the name of the file of dir with the given content,
+// or an empty string if there is none
+func findAsset(dir string, content []byte) (string, error) {
+	prefix := assetName(content, "")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), prefix) {
+			return e.Name(), nil
+		}
+	}
+	return "", nil
+}
+
+// Attachments returns the files attached to the notes of project, or of all
+// projects if project is empty, with the notes linking to them.
+// Archived notes count as links too
+func (gn *GN) Attachments(project string) ([]Attachment, error) {
+	projects := []string{project}
+	if project == "" {
+		entries, err := os.ReadDir(gn.NotesPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		projects = []string{}
+		for _, e := range entries {
+			if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+				projects = append(projects, e.Name())
+			}
+		}
+	}
+
+	// notes of any project may link to the attachments, e.g. after a move
+	notes, err := gn.loadNotes(NoteFilter{Archived: true})
+	if err != nil {
+		return nil, err
+	}
+	linked := map[string][]NoteRef{}
+	for _, n := range notes {
+		path := n.NoteRef.path(gn.NotesPath)
+		if n.Archived {
+			path = ArchivedNote{Project: n.Project, Branch: n.Branch}.path(gn.NotesPath)
+		}
+		for _, f := range linkedFiles(n.Content, filepath.Dir(path)) {
+			// a note may link to the same file more than once
+			if refs := linked[f]; len(refs) > 0 && refs[len(refs)-1] == n.NoteRef {
+				continue
+			}
+			linked[f] = append(linked[f], n.NoteRef)
+		}
+	}
+
+	attachments := []Attachment{}
+	for _, p := range projects {
+		dir := filepath.Join(gn.NotesPath, p, assetsDir)
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				return nil, err
+			}
+			a := Attachment{Project: p, Name: e.Name(), Size: info.Size(), Notes: []NoteRef{}}
+			a.Notes = append(a.Notes, linked[filepath.Join(dir, e.Name())]...)
+			attachments = append(attachments, a)
+		}
+	}
+
+	sort.SliceStable(attachments, func(i, j int) bool {
+		if attachments[i].Project != attachments[j].Project {
+			return attachments[i].Project < attachments[j].Project
+		}
+		return attachments[i].Name < attachments[j].Name
+	})
+	return attachments, nil
+}
@@ -0,0 +1,120 @@
+package gn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttach(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/refunds"
+	writeTestNote(t, gn, "billing", "feat/refunds", "# Refunds\n")
+
+	dir := t.TempDir()
+	screenshot := filepath.Join(dir, "screen.png")
+	assert.NoError(t, os.WriteFile(screenshot, []byte("png"), 0644))
+	copied := filepath.Join(dir, "same.png")
+	assert.NoError(t, os.WriteFile(copied, []byte("png"), 0644))
+
+	link, err := gn.Attach(screenshot)
+	assert.NoError(t, err)
+	name := assetName([]byte("png"), "screen.png")
+	assert.Equal(t, "![screen.png](../.assets/"+name+")", link)
+	assert.Equal(t, "# Refunds\n"+link+"\n", readTestNote(t, gn, "billing", "feat/refunds"))
+
+	// the same content is stored once
+	_, err = gn.Attach(copied)
+	assert.NoError(t, err)
+	entries, err := os.ReadDir(filepath.Join(gn.NotesPath, "billing", assetsDir))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	_, err = gn.Attach(filepath.Join(dir, "missing.log"))
+	assert.Error(t, err)
+}
+
+func TestAttachments(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "main"
+	writeTestNote(t, gn, "billing", "main", "# Notes\n")
+
+	file := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, os.WriteFile(file, []byte("log"), 0644))
+	_, err := gn.Attach(file)
+	assert.NoError(t, err)
+
+	attachments, err := gn.Attachments("")
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+	assert.Equal(t, assetName([]byte("log"), "app.log"), attachments[0].Name)
+	assert.Equal(t, []NoteRef{{Project: "billing", Branch: "main"}}, attachments[0].Notes)
+
+	// removing the link leaves the attachment unreferenced
+	writeTestNote(t, gn, "billing", "main", "# Notes\n")
+	attachments, err = gn.Attachments("billing")
+	assert.NoError(t, err)
+	assert.Empty(t, attachments[0].Notes)
+}
+
+func TestRebaseAssetLinks(t *testing.T) {
+	notes := filepath.Join("notes", "billing")
+	tests := []struct {
+		content  string
+		from, to string
+		expected string
+	}{
+		{"![s](../.assets/a.png)", filepath.Join(notes, "feat"), notes, "![s](.assets/a.png)"},
+		{"[log](.assets/a.log) and [x](.assets/b)", notes, filepath.Join(notes, "feat", "x"), "[log](../../.assets/a.log) and [x](../../.assets/b)"},
+		{"[s](<../.assets/my shot.png>)", filepath.Join(notes, "feat"), filepath.Join("notes", ".archive", "billing", "feat", "main"), "[s](<../../../../billing/.assets/my shot.png>)"},
+		{"[site](https://example.com/.assets/a) [doc](../docs/a.md)", notes, filepath.Join(notes, "feat"), "[site](https://example.com/.assets/a) [doc](../docs/a.md)"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, string(rebaseAssetLinks([]byte(tt.content), tt.from, tt.to)), tt.content)
+	}
+}
+
+func TestMoveKeepsAttachmentLinks(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/refunds"
+	writeTestNote(t, gn, "billing", "feat/refunds", "# Refunds\n")
+
+	file := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, os.WriteFile(file, []byte("log"), 0644))
+	_, err := gn.Attach(file)
+	assert.NoError(t, err)
+
+	dst := NoteRef{Project: "billing", Branch: "refunds"}
+	err = gn.Move(NoteRef{Project: "billing", Branch: "feat/refunds"}, dst, ConflictFail)
+	assert.NoError(t, err)
+	name := assetName([]byte("log"), "app.log")
+	assert.Equal(t, "# Refunds\n[app.log](.assets/"+name+")\n", readTestNote(t, gn, "billing", "refunds"))
+	_, err = os.Stat(filepath.Join(filepath.Dir(dst.path(gn.NotesPath)), ".assets", name))
+	assert.NoError(t, err)
+}
+
+func TestAttachmentsLinkedFromOtherProjects(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/refunds"
+	writeTestNote(t, gn, "billing", "feat/refunds", "# Refunds\n")
+
+	file := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, os.WriteFile(file, []byte("log"), 0644))
+	_, err := gn.Attach(file)
+	assert.NoError(t, err)
+
+	dst := NoteRef{Project: "shop", Branch: "refunds"}
+	err = gn.Move(NoteRef{Project: "billing", Branch: "feat/refunds"}, dst, ConflictFail)
+	assert.NoError(t, err)
+
+	attachments, err := gn.Attachments("billing")
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+	assert.Equal(t, []NoteRef{dst}, attachments[0].Notes)
+}
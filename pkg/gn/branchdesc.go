@@ -0,0 +1,137 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// defaultBranchDescSection is the note section synced with the
+// branch description if the config does not set another
+const defaultBranchDescSection = "Summary"
+
+// branchDescSection returns the note section synced with the branch description
+func (gn *GN) branchDescSection() string {
+	if gn.BranchDescSection != "" {
+		return gn.BranchDescSection
+	}
+	return defaultBranchDescSection
+}
+
+// PushBranchDesc sets the description of the branch of the current note
+// (branch.<name>.description) in the working repository to the
+// branch description section of the note. It returns the description
+func (gn *GN) PushBranchDesc() (string, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return "", err
+	}
+	content, err := gn.readNote(ref)
+	if err != nil {
+		return "", err
+	}
+	_, body, _ := ParseFrontmatter(content)
+	section := gn.branchDescSection()
+	desc, ok := extractSection(body, section)
+	if !ok {
+		return "", errflags.New(fmt.Sprintf("note %s has no %s section", ref, section), errflags.NotFound)
+	}
+
+	r, err := gn.openBranchRepo(ref.Branch)
+	if err != nil {
+		return "", err
+	}
+	return desc, setBranchDescription(r, ref.Branch, desc)
+}
+
+// PullBranchDesc replaces the branch description section of the current note
+// with the description of its branch in the working repository.
+// The note is commited if AlwaysCommit is set. It returns the description
+func (gn *GN) PullBranchDesc() (string, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return "", err
+	}
+
+	r, err := gn.openBranchRepo(ref.Branch)
+	if err != nil {
+		return "", err
+	}
+	cfg, err := r.Config()
+	if err != nil {
+		return "", err
+	}
+	b, ok := cfg.Branches[ref.Branch]
+	if !ok || strings.TrimSpace(b.Description) == "" {
+		return "", errflags.New(fmt.Sprintf("branch %s has no description", ref.Branch), errflags.NotFound)
+	}
+
+	notePath := ref.path(gn.NotesPath)
+	before, err := os.ReadFile(notePath)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(notePath), os.ModeDir|0700); err != nil {
+		return "", err
+	}
+	content := replaceSection(string(before), gn.branchDescSection(), b.Description)
+	if err := os.WriteFile(notePath, []byte(content), 0644); err != nil {
+		return "", err
+	}
+	if err := gn.touchNote(notePath, before); err != nil {
+		return "", err
+	}
+
+	if !gn.AlwaysCommit {
+		return b.Description, nil
+	}
+	return b.Description, gn.commitPaths(fmt.Sprintf("Pull branch description of %s", ref), filepath.Join(ref.Project, ref.Branch))
+}
+
+// openBranchRepo opens the working repository and checks that branch is one of its local branches
+func (gn *GN) openBranchRepo(branch string) (*git.Repository, error) {
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Reference(plumbing.NewBranchReferenceName(branch), false); err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, errflags.Flag(fmt.Errorf("branch %s not found", branch), errflags.NotFound)
+		}
+		return nil, err
+	}
+	return r, nil
+}
+
+// setBranchDescription sets the description of branch in r.
+// An empty description removes it. It goes through git itself: go-git
+// rewrites every branch section when saving the config and escapes the
+// newlines of multi-line descriptions twice, corrupting them
+func setBranchDescription(r *git.Repository, branch string, desc string) error {
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	key := "branch." + branch + ".description"
+	cmd := exec.Command("git", "config", key, desc)
+	if desc == "" {
+		cmd = exec.Command("git", "config", "--unset", key)
+	}
+	cmd.Dir = w.Filesystem.Root()
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		// git config --unset exits with 5 if the key is not set
+		if exitErr, ok := err.(*exec.ExitError); ok && desc == "" && exitErr.ExitCode() == 5 {
+			return nil
+		}
+		return fmt.Errorf("git config: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package gn
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushAndPullBranchDesc(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/login", true)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/login"
+	writeTestNote(t, gn, "billing", "feat/login", "# Login\n## Summary\nAdds login.\n\nWith tokens.\n## Testing\nunit\n")
+
+	desc, err := gn.PushBranchDesc()
+	assert.NoError(t, err)
+	assert.Equal(t, "Adds login.\n\nWith tokens.", desc)
+
+	// git reads the same description
+	out, err := exec.Command("git", "config", "branch.feat/login.description").Output()
+	assert.NoError(t, err)
+	assert.Equal(t, desc, strings.TrimSpace(string(out)))
+
+	err = exec.Command("git", "config", "branch.feat/login.description", "Edited in git").Run()
+	assert.NoError(t, err)
+	desc, err = gn.PullBranchDesc()
+	assert.NoError(t, err)
+	assert.Equal(t, "Edited in git", desc)
+	assert.Equal(t, "# Login\n## Summary\n\nEdited in git\n\n## Testing\nunit\n", readTestNote(t, gn, "billing", "feat/login"))
+
+	// single line descriptions are read by go-git too
+	desc, err = gn.PushBranchDesc()
+	assert.NoError(t, err)
+	cfg, err := tr.repo.Config()
+	assert.NoError(t, err)
+	assert.Equal(t, desc, cfg.Branches["feat/login"].Description)
+
+	gn.Branch = "missing"
+	_, err = gn.PushBranchDesc()
+	assert.Error(t, err)
+}
+
+func TestPushBranchDescKeepsOtherBranches(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/a", true)
+	tr.checkout("feat/b", true)
+	err := exec.Command("git", "config", "branch.feat/a.description", "line1\nline2").Run()
+	assert.NoError(t, err)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/b"
+	writeTestNote(t, gn, "billing", "feat/b", "# B\n## Summary\nOne line.\n")
+
+	_, err = gn.PushBranchDesc()
+	assert.NoError(t, err)
+	out, err := exec.Command("git", "config", "branch.feat/a.description").Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "line1\nline2\n", string(out))
+	out, err = exec.Command("git", "config", "branch.feat/b.description").Output()
+	assert.NoError(t, err)
+	assert.Equal(t, "One line.\n", string(out))
+}
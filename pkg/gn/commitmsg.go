@@ -0,0 +1,252 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// defaultCommitMsgSection is the note section `gn commit-msg` drafts
+// commit messages from if the config does not set another
+const defaultCommitMsgSection = "Next commit"
+
+// commitMsgTrailer is the trailer linking a commit to the revision of the notes
+const commitMsgTrailer = "Gitnotes-Ref"
+
+// scissors is the line after which git ignores the commit message, e.g. the diff of `git commit -v`
+const scissors = "------------------------ >8 ------------------------"
+
+// trailerRegex matches git trailer lines, e.g. "Signed-off-by: Name <email>"
+var trailerRegex = regexp.MustCompile(`^[A-Za-z0-9-]+: `)
+
+// commitMsgSection returns the note section commit messages are drafted from
+func (gn *GN) commitMsgSection() string {
+	if gn.CommitMsgSection != "" {
+		return gn.CommitMsgSection
+	}
+	return defaultCommitMsgSection
+}
+
+// CommitMsg prepares the commit message at file, as a prepare-commit-msg hook
+// does. source is the second argument git passes to the hook.
+// If the message is still empty, it is filled with the commit message section
+// of the current note. If CommitMsgTrailer is set, the note is commited and a
+// Gitnotes-Ref trailer with the notes revision is added to non-empty messages.
+// Merges, squashes and branches without a note are left as they are.
+// It returns whether file changed
+func (gn *GN) CommitMsg(file string, source string) (bool, error) {
+	if source == "merge" || source == "squash" {
+		return false, nil
+	}
+
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return false, err
+	}
+	content, err := gn.readNote(ref)
+	if err != nil {
+		if errflags.HasFlag(err, errflags.NotFound) {
+			gn.log.Debug("no note for %s, leaving the commit message as it is", ref)
+			return false, nil
+		}
+		return false, err
+	}
+	_, body, _ := ParseFrontmatter(content)
+
+	msg, err := os.ReadFile(file)
+	if err != nil {
+		return false, err
+	}
+	comment := gn.commentChar(string(msg))
+	lines, ignored := cutScissors(strings.Split(string(msg), "\n"), comment)
+	last := lastMessageLine(lines, comment)
+
+	if last < 0 && source != "message" && source != "commit" {
+		draft, _ := extractSection(body, gn.commitMsgSection())
+		if draft != "" {
+			for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+				lines = lines[1:]
+			}
+			lines = append([]string{draft, ""}, lines...)
+			last = lastMessageLine(lines, comment)
+		}
+	}
+
+	if gn.CommitMsgTrailer && last >= 0 && !hasTrailer(lines[:last+1], commitMsgTrailer) {
+		sha, err := gn.notesRevision(ref)
+		if err != nil {
+			return false, err
+		}
+		if sha != "" {
+			lines = addTrailer(lines, last, commitMsgTrailer+": "+sha)
+		}
+	}
+
+	updated := strings.Join(append(lines, ignored...), "\n")
+	if updated == string(msg) {
+		return false, nil
+	}
+	return true, os.WriteFile(file, []byte(updated), 0644)
+}
+
+// ClearCommitMsg empties the commit message section of the current note
+// if the last commit of the working repository used it, so it can be called
+// from a post-commit hook. The note is commited if AlwaysCommit is set.
+// It returns whether the section was cleared
+func (gn *GN) ClearCommitMsg() (bool, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return false, err
+	}
+	content, err := gn.readNote(ref)
+	if err != nil {
+		return false, err
+	}
+	_, body, _ := ParseFrontmatter(content)
+	section := gn.commitMsgSection()
+	draft, _ := extractSection(body, section)
+	if draft == "" {
+		return false, nil
+	}
+
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return false, err
+	}
+	head, err := r.Head()
+	if err != nil {
+		return false, err
+	}
+	commit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		return false, err
+	}
+	// the message may have been edited, the subject is enough to tell it was used
+	subject, _, _ := strings.Cut(draft, "\n")
+	if !strings.Contains(commit.Message, strings.TrimSpace(subject)) {
+		gn.log.Debug("last commit does not use the %s section, keeping it", section)
+		return false, nil
+	}
+
+	notePath := ref.path(gn.NotesPath)
+	if err := os.WriteFile(notePath, []byte(replaceSection(content, section, "")), 0644); err != nil {
+		return false, err
+	}
+	if err := gn.touchNote(notePath, []byte(content)); err != nil {
+		return false, err
+	}
+
+	if !gn.AlwaysCommit {
+		return true, nil
+	}
+	return true, gn.commitPaths(fmt.Sprintf("Clear %s section of %s", section, ref), filepath.Join(ref.Project, ref.Branch))
+}
+
+// notesRevision commits the note of ref and returns the short hash of the
+// notes repository HEAD, or an empty string if it has no commits yet
+func (gn *GN) notesRevision(ref NoteRef) (string, error) {
+	if err := gn.commitPaths(fmt.Sprintf("Update %s", ref), filepath.Join(ref.Project, ref.Branch)); err != nil {
+		return "", err
+	}
+	r, err := git.PlainOpen(gn.NotesPath)
+	if err != nil {
+		return "", err
+	}
+	head, err := r.Head()
+	if err != nil {
+		gn.log.Debug("failed to read notes HEAD: %s", err.Error())
+		return "", nil
+	}
+	return head.Hash().String()[:12], nil
+}
+
+// commentChar returns the character starting the comments of the commit
+// message msg: core.commentChar of the working repository, or # if it is not set.
+// With core.commentChar=auto, it is the first candidate git uses that starts a line of msg
+func (gn *GN) commentChar(msg string) string {
+	char := ""
+	if r, err := gn.openWorkingRepo(); err == nil {
+		if cfg, err := r.Config(); err == nil {
+			char = cfg.Raw.Section("core").Option("commentChar")
+		}
+	}
+
+	switch char {
+	case "":
+		return "#"
+	case "auto":
+		for _, line := range strings.Split(msg, "\n") {
+			if line != "" && strings.ContainsRune("#;@!$%^&|:", rune(line[0])) {
+				return line[:1]
+			}
+		}
+		return "#"
+	}
+	return char
+}
+
+// cutScissors splits the lines of a commit message at the scissors line,
+// returning the message and the lines git ignores, starting at the scissors
+func cutScissors(lines []string, comment string) ([]string, []string) {
+	for i, line := range lines {
+		if line == comment+" "+scissors {
+			// the full slice expression keeps appends to the message from overwriting the rest
+			return lines[:i:i], lines[i:]
+		}
+	}
+	return lines, nil
+}
+
+// lastMessageLine returns the index of the last line of a commit message
+// that is neither blank nor a comment, or -1 if the message is empty
+func lastMessageLine(lines []string, comment string) int {
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.TrimSpace(lines[i]) != "" && !strings.HasPrefix(lines[i], comment) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasTrailer reports whether a commit message has a trailer with the given token
+func hasTrailer(lines []string, token string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(strings.ToLower(line), strings.ToLower(token)+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// addTrailer adds trailer after the line last of a commit message,
+// in the same paragraph if it is already a block of trailers
+func addTrailer(lines []string, last int, trailer string) []string {
+	start := last
+	for start > 0 && strings.TrimSpace(lines[start-1]) != "" {
+		start--
+	}
+	inserted := []string{trailer}
+	if start == 0 || !isTrailerBlock(lines[start:last+1]) {
+		// the subject is never a trailer
+		inserted = []string{"", trailer}
+	}
+
+	added := append([]string{}, lines[:last+1]...)
+	added = append(added, inserted...)
+	return append(added, lines[last+1:]...)
+}
+
+// isTrailerBlock reports whether all the lines of a paragraph are trailers
+func isTrailerBlock(lines []string) bool {
+	for _, line := range lines {
+		if !trailerRegex.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,134 @@
+package gn
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommitMsg(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/login", true)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/login"
+	writeTestNote(t, gn, "billing", "feat/login", "# Login\n## Next commit\nAdd login form\n\nWith validation.\n## Notes\nx\n")
+
+	file := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	comments := "\n# Please enter the commit message for your changes.\n"
+	assert.NoError(t, os.WriteFile(file, []byte(comments), 0644))
+
+	changed, err := gn.CommitMsg(file, "")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	msg, _ := os.ReadFile(file)
+	assert.Equal(t, "Add login form\n\nWith validation.\n"+comments, string(msg))
+
+	// messages given with -m are kept
+	assert.NoError(t, os.WriteFile(file, []byte("Fix typo\n"), 0644))
+	changed, err = gn.CommitMsg(file, "message")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	gn.CommitMsgTrailer = true
+	changed, err = gn.CommitMsg(file, "message")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	r, err := git.PlainOpen(gn.NotesPath)
+	assert.NoError(t, err)
+	head, err := r.Head()
+	assert.NoError(t, err)
+	trailer := "Gitnotes-Ref: " + head.Hash().String()[:12]
+	msg, _ = os.ReadFile(file)
+	assert.Equal(t, "Fix typo\n\n"+trailer+"\n", string(msg))
+
+	// the trailer is added once, and joins other trailers
+	changed, err = gn.CommitMsg(file, "commit")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.NoError(t, os.WriteFile(file, []byte("Fix typo\n\nSigned-off-by: test\n"), 0644))
+	_, err = gn.CommitMsg(file, "message")
+	assert.NoError(t, err)
+	msg, _ = os.ReadFile(file)
+	assert.Equal(t, "Fix typo\n\nSigned-off-by: test\n"+trailer+"\n", string(msg))
+
+	// merges are left alone
+	assert.NoError(t, os.WriteFile(file, []byte("Merge branch main\n"), 0644))
+	changed, err = gn.CommitMsg(file, "merge")
+	assert.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestCommitMsgVerbose(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/login", true)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/login"
+	gn.CommitMsgTrailer = true
+	writeTestNote(t, gn, "billing", "feat/login", "# Login\n## Next commit\nAdd login form\n")
+
+	// git commit -v adds the diff below the scissors line
+	file := filepath.Join(t.TempDir(), "COMMIT_EDITMSG")
+	verbose := "; ------------------------ >8 ------------------------\n" +
+		"; Do not modify or remove the line above.\n" +
+		"diff --git a/login.go b/login.go\n" +
+		"+package login\n"
+	assert.NoError(t, os.WriteFile(file, []byte("\n; Please enter the commit message for your changes.\n"+verbose), 0644))
+	err := exec.Command("git", "config", "core.commentChar", ";").Run()
+	assert.NoError(t, err)
+
+	changed, err := gn.CommitMsg(file, "")
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	r, err := git.PlainOpen(gn.NotesPath)
+	assert.NoError(t, err)
+	head, err := r.Head()
+	assert.NoError(t, err)
+	msg, _ := os.ReadFile(file)
+	expected := "Add login form\n\nGitnotes-Ref: " + head.Hash().String()[:12] + "\n\n" +
+		"; Please enter the commit message for your changes.\n" + verbose
+	assert.Equal(t, expected, string(msg))
+}
+
+func TestClearCommitMsg(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/login", true)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/login"
+	writeTestNote(t, gn, "billing", "feat/login", "# Login\n## Next commit\nAdd login form\n## Notes\nx\n")
+
+	cleared, err := gn.ClearCommitMsg()
+	assert.NoError(t, err)
+	assert.False(t, cleared)
+
+	tr.commitFile("login.go", "package login\n", "Add login form\n\nEdited body.")
+	cleared, err = gn.ClearCommitMsg()
+	assert.NoError(t, err)
+	assert.True(t, cleared)
+	assert.Equal(t, "# Login\n## Next commit\n\n## Notes\nx\n", readTestNote(t, gn, "billing", "feat/login"))
+}
+
+func TestClearCommitMsgCodeBlock(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/login", true)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/login"
+	writeTestNote(t, gn, "billing", "feat/login", "# Login\n## Next commit\nAdd login script\n\n```sh\n# run it\n./login.sh\n```\n## Notes\nx\n")
+
+	tr.commitFile("login.sh", "echo login\n", "Add login script")
+	cleared, err := gn.ClearCommitMsg()
+	assert.NoError(t, err)
+	assert.True(t, cleared)
+	assert.Equal(t, "# Login\n## Next commit\n\n## Notes\nx\n", readTestNote(t, gn, "billing", "feat/login"))
+}
@@ -0,0 +1,278 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// contextStart and contextEnd delimit the git context block of a note.
+// `gn context` replaces what is between them
+const (
+	contextStart = "<!-- gn-context -->"
+	contextEnd   = "<!-- /gn-context -->"
+)
+
+// GitContext is a snapshot of the state of a branch of the working repository
+type GitContext struct {
+	Branch        string
+	DefaultBranch string
+	// MergeBase is the merge base of the branch and the default branch
+	MergeBase plumbing.Hash
+	// Commits are the commits of the branch since MergeBase, newest first
+	Commits []*object.Commit
+	// Stats are the changes of the branch since MergeBase
+	Stats object.FileStats
+	// Upstream is the upstream of the branch, e.g. origin/feat/login.
+	// Ahead and Behind count the commits that differ from it
+	Upstream string
+	Ahead    int
+	Behind   int
+	// Uncommitted are the changed files of the working tree, in `git status --short` form.
+	// They are only set if the branch is checked out
+	Uncommitted []string
+	Time        time.Time
+}
+
+// GitContext returns a snapshot of the branch of the current note
+// in the working repository
+func (gn *GN) GitContext() (GitContext, error) {
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return GitContext{}, err
+	}
+
+	branch, err := gn.findBranch()
+	if err != nil {
+		return GitContext{}, err
+	}
+	head, defaultBranch, base, err := gn.branchBase(r, branch)
+	if err != nil {
+		return GitContext{}, err
+	}
+
+	c := GitContext{Branch: branch, DefaultBranch: defaultBranch, Time: time.Now()}
+	if base != nil {
+		c.MergeBase = base.Hash
+		if c.Commits, err = revList(r, head.Hash, base.Hash); err != nil {
+			return GitContext{}, err
+		}
+		patch, err := base.Patch(head)
+		if err != nil {
+			return GitContext{}, err
+		}
+		c.Stats = patch.Stats()
+	}
+
+	if err := c.setUpstream(r, head.Hash); err != nil {
+		return GitContext{}, err
+	}
+
+	current, err := getCurrentBranch(r)
+	if err == nil && current == branch {
+		if c.Uncommitted, err = uncommittedFiles(r); err != nil {
+			return GitContext{}, err
+		}
+	}
+
+	return c, nil
+}
+
+// branchBase returns the tip of branch in r, the name of the default branch
+// and the merge base of both. The merge base is nil if they share no history
+func (gn *GN) branchBase(r *git.Repository, branch string) (*object.Commit, string, *object.Commit, error) {
+	ref, err := resolveBranch(r, branch)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, "", nil, errflags.Flag(fmt.Errorf("branch %s not found", branch), errflags.NotFound)
+		}
+		return nil, "", nil, err
+	}
+	head, err := r.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	defaultBranch, err := gn.defaultBranch(r)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	defaultRef, err := resolveBranch(r, defaultBranch)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	defaultCommit, err := r.CommitObject(defaultRef.Hash())
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	bases, err := head.MergeBase(defaultCommit)
+	if err != nil || len(bases) == 0 {
+		return head, defaultBranch, nil, err
+	}
+	return head, defaultBranch, bases[0], nil
+}
+
+// setUpstream sets the upstream of the branch, whose tip is head,
+// and how far ahead and behind of it the branch is
+func (c *GitContext) setUpstream(r *git.Repository, head plumbing.Hash) error {
+	upstream, err := findUpstream(r, c.Branch)
+	if err != nil || upstream == "" {
+		return err
+	}
+	c.Upstream = upstream.Short()
+
+	ref, err := r.Reference(upstream, true)
+	if err != nil {
+		// the upstream was not fetched yet
+		if err == plumbing.ErrReferenceNotFound {
+			return nil
+		}
+		return err
+	}
+
+	ahead, err := revList(r, head, ref.Hash())
+	if err != nil {
+		return err
+	}
+	behind, err := revList(r, ref.Hash(), head)
+	if err != nil {
+		return err
+	}
+	c.Ahead, c.Behind = len(ahead), len(behind)
+	return nil
+}
+
+// uncommittedFiles returns the changed files of the working tree of r,
+// in `git status --short` form
+func uncommittedFiles(r *git.Repository) ([]string, error) {
+	w, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	status, err := w.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{}
+	for name, s := range status {
+		if s.Staging == git.Unmodified && s.Worktree == git.Unmodified {
+			continue
+		}
+		files = append(files, fmt.Sprintf("%c%c %s", s.Staging, s.Worktree, name))
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i][3:] < files[j][3:]
+	})
+	return files, nil
+}
+
+// Markdown returns the snapshot as a Markdown block delimited
+// by the context markers
+func (c GitContext) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString(contextStart + "\n")
+	sb.WriteString("## Git context\n\n")
+	sb.WriteString(fmt.Sprintf("Snapshot of `%s` taken on %s", c.Branch, c.Time.Format("2006-01-02 15:04")))
+	if !c.MergeBase.IsZero() {
+		sb.WriteString(fmt.Sprintf(", since merge base `%s` with `%s`", c.MergeBase.String()[:7], c.DefaultBranch))
+	}
+	sb.WriteString(".\n\n")
+
+	if c.Upstream != "" {
+		sb.WriteString(fmt.Sprintf("Upstream: `%s`, ahead %d, behind %d.\n\n", c.Upstream, c.Ahead, c.Behind))
+	} else {
+		sb.WriteString("Upstream: none.\n\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("### Commits (%d)\n\n", len(c.Commits)))
+	for _, commit := range c.Commits {
+		subject, _, _ := strings.Cut(commit.Message, "\n")
+		sb.WriteString(fmt.Sprintf("- %s %s\n", commit.Hash.String()[:7], subject))
+	}
+	if len(c.Commits) > 0 {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("### Diffstat\n\n")
+	if len(c.Stats) > 0 {
+		additions, deletions := 0, 0
+		for _, s := range c.Stats {
+			additions += s.Addition
+			deletions += s.Deletion
+		}
+		sb.WriteString("```\n")
+		sb.WriteString(c.Stats.String())
+		sb.WriteString(fmt.Sprintf(" %d files changed, %d insertions(+), %d deletions(-)\n", len(c.Stats), additions, deletions))
+		sb.WriteString("```\n\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("### Uncommitted files (%d)\n\n", len(c.Uncommitted)))
+	for _, f := range c.Uncommitted {
+		sb.WriteString(fmt.Sprintf("- `%s`\n", f))
+	}
+	if len(c.Uncommitted) > 0 {
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString(contextEnd + "\n")
+	return sb.String()
+}
+
+// InsertContext writes the snapshot c into the current note. It replaces the
+// block between the context markers if the note has one, or appends it otherwise.
+// The note is commited if AlwaysCommit is set
+func (gn *GN) InsertContext(c GitContext) error {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return err
+	}
+
+	notePath := ref.path(gn.NotesPath)
+	before, err := os.ReadFile(notePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(notePath), os.ModeDir|0700); err != nil {
+		return err
+	}
+	content := replaceContext(string(before), c.Markdown())
+	if err := os.WriteFile(notePath, []byte(content), 0644); err != nil {
+		return err
+	}
+	if err := gn.touchNote(notePath, before); err != nil {
+		return err
+	}
+
+	if !gn.AlwaysCommit {
+		return nil
+	}
+	return gn.commitPaths(fmt.Sprintf("Update git context of %s", ref), filepath.Join(ref.Project, ref.Branch))
+}
+
+// replaceContext replaces the context block of content with block,
+// or appends block if content has none
+func replaceContext(content string, block string) string {
+	start := strings.Index(content, contextStart)
+	end := strings.Index(content, contextEnd)
+	if start < 0 || end < start {
+		return string(appendNote([]byte(content), []byte(block)))
+	}
+
+	end += len(contextEnd)
+	// the trailing newline is part of the block
+	if strings.HasPrefix(content[end:], "\n") {
+		end++
+	}
+	return content[:start] + block + content[end:]
+}
@@ -0,0 +1,74 @@
+package gn
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitContext(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/login", true)
+	tr.commitFile("login.go", "package login\n", "Add login")
+	tip := tr.commitFile("login.go", "package login\n\nfunc Login() {}\n", "Add Login func")
+
+	// the upstream has the first commit only
+	head, err := tr.repo.Head()
+	assert.NoError(t, err)
+	tipCommit, err := tr.repo.CommitObject(tip)
+	assert.NoError(t, err)
+	err = tr.repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "feat/login"), tipCommit.ParentHashes[0]))
+	assert.NoError(t, err)
+	tr.setUpstream("feat/login", "origin", "feat/login")
+
+	err = os.WriteFile(filepath.Join(tr.dir, "README.md"), []byte("changed\n"), 0644)
+	assert.NoError(t, err)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/login"
+	c, err := gn.GitContext()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "main", c.DefaultBranch)
+	assert.Len(t, c.Commits, 2)
+	assert.Equal(t, head.Hash(), c.Commits[0].Hash)
+	assert.Len(t, c.Stats, 1)
+	assert.Equal(t, "login.go", c.Stats[0].Name)
+	assert.Equal(t, "origin/feat/login", c.Upstream)
+	assert.Equal(t, 1, c.Ahead)
+	assert.Equal(t, 0, c.Behind)
+	assert.Equal(t, []string{" M README.md"}, c.Uncommitted)
+
+	md := c.Markdown()
+	assert.True(t, strings.HasPrefix(md, contextStart+"\n"))
+	assert.Contains(t, md, "Add Login func")
+	assert.Contains(t, md, "ahead 1, behind 0")
+}
+
+func TestInsertContext(t *testing.T) {
+	newTestRepo(t)
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "main"
+	writeTestNote(t, gn, "billing", "main", "# Notes\n")
+
+	c, err := gn.GitContext()
+	assert.NoError(t, err)
+	assert.Empty(t, c.Commits)
+
+	assert.NoError(t, gn.InsertContext(c))
+	content := readTestNote(t, gn, "billing", "main")
+	assert.True(t, strings.HasPrefix(content, "# Notes\n"+contextStart))
+
+	// a second snapshot replaces the first one
+	writeTestNote(t, gn, "billing", "main", content+"\n## After\n")
+	assert.NoError(t, gn.InsertContext(c))
+	content = readTestNote(t, gn, "billing", "main")
+	assert.Equal(t, 1, strings.Count(content, contextStart))
+	assert.True(t, strings.HasSuffix(content, contextEnd+"\n\n## After\n"))
+}
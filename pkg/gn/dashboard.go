@@ -0,0 +1,71 @@
+package gn
+
+import (
+	"sort"
+	"time"
+)
+
+// DashboardNote is a note as shown in the dashboard
+type DashboardNote struct {
+	NoteRef
+	Pinned    bool
+	Status    string
+	OpenTodos int
+	Modified  time.Time
+}
+
+// Dashboard holds the notes being worked on
+type Dashboard struct {
+	// Pinned are the pinned notes
+	Pinned []DashboardNote
+	// Recent are the other notes edited since the given time, most recent first
+	Recent []DashboardNote
+}
+
+// Dashboard returns the pinned notes and the notes edited since since
+func (gn *GN) Dashboard(since time.Time) (Dashboard, error) {
+	pins, err := gn.Pins()
+	if err != nil {
+		return Dashboard{}, err
+	}
+	pinned := map[NoteRef]bool{}
+	for _, p := range pins {
+		pinned[p] = true
+	}
+
+	notes, err := gn.loadNotes(NoteFilter{Modified: true})
+	if err != nil {
+		return Dashboard{}, err
+	}
+
+	d := Dashboard{Pinned: []DashboardNote{}, Recent: []DashboardNote{}}
+	for _, n := range notes {
+		isPinned := pinned[n.NoteRef]
+		if !isPinned && n.Modified.Before(since) {
+			continue
+		}
+
+		dn := DashboardNote{
+			NoteRef:  n.NoteRef,
+			Pinned:   isPinned,
+			Status:   noteStatus(n.Content),
+			Modified: n.Modified,
+		}
+		for _, t := range parseTodos(n.NoteRef, n.Content) {
+			if !t.Done {
+				dn.OpenTodos++
+			}
+		}
+
+		if isPinned {
+			d.Pinned = append(d.Pinned, dn)
+		} else {
+			d.Recent = append(d.Recent, dn)
+		}
+	}
+
+	sort.SliceStable(d.Recent, func(i, j int) bool {
+		return d.Recent[i].Modified.After(d.Recent[j].Modified)
+	})
+	return d, nil
+}
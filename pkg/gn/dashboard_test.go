@@ -0,0 +1,31 @@
+package gn
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDashboard(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "Status: done\n")
+	writeTestNote(t, gn, "billing", "feat/refunds", "- [ ] tests\n- [x] code\n- [ ] docs\n")
+	writeTestNote(t, gn, "shop", "main", "")
+
+	old := time.Now().AddDate(0, 0, -30)
+	assert.NoError(t, os.Chtimes(getNotePath(gn.NotesPath, "billing", "main"), old, old))
+	assert.NoError(t, os.Chtimes(getNotePath(gn.NotesPath, "shop", "main"), old, old))
+	assert.NoError(t, gn.Pin(NoteRef{Project: "billing", Branch: "main"}))
+
+	d, err := gn.Dashboard(time.Now().AddDate(0, 0, -7))
+	assert.NoError(t, err)
+
+	assert.Len(t, d.Pinned, 1)
+	assert.Equal(t, "billing/main", d.Pinned[0].String())
+	assert.Equal(t, "done", d.Pinned[0].Status)
+	assert.Len(t, d.Recent, 1)
+	assert.Equal(t, "billing/feat/refunds", d.Recent[0].String())
+	assert.Equal(t, 2, d.Recent[0].OpenTodos)
+}
@@ -0,0 +1,231 @@
+package gn
+
+import (
+	"regexp"
+	"strings"
+)
+
+// frontmatterDelimiter starts and ends the frontmatter of a note
+const frontmatterDelimiter = "---"
+
+// frontmatterKeyRegex matches valid frontmatter keys
+var frontmatterKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Frontmatter is the metadata block at the top of a note, between two --- lines,
+// with fields like status, tags, owner, ticket, created and updated.
+// Only a flat subset of YAML is supported: key: value lines, and lists
+// written inline (tags: [a, b]) or as "- item" lines. Lines gn does not
+// understand are kept as they are
+type Frontmatter struct {
+	lines []string
+}
+
+// splitFrontmatter splits the frontmatter, the lines between two ---
+// lines at the top of a note, from the rest of the note.
+// ok is false if content has no frontmatter. A note starting with a
+// horizontal rule has none: the lines up to the next --- must be empty
+// or key: value lines
+func splitFrontmatter(content string) (frontmatter string, body string, ok bool) {
+	first, rest, found := strings.Cut(content, "\n")
+	if !found || strings.TrimSpace(first) != frontmatterDelimiter {
+		return "", content, false
+	}
+
+	lines := strings.Split(rest, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == frontmatterDelimiter {
+			if !isFrontmatter(lines[:i]) {
+				return "", content, false
+			}
+			return strings.Join(lines[:i], "\n"), strings.Join(lines[i+1:], "\n"), true
+		}
+	}
+
+	return "", content, false
+}
+
+// isFrontmatter reports whether lines are a frontmatter gn can read: blank
+// lines, comments and key: value lines, each key followed by the indented
+// or "- item" lines of its value
+func isFrontmatter(lines []string) bool {
+	hasKey := false
+	for _, line := range lines {
+		switch {
+		case strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "-"):
+			if !hasKey {
+				return false
+			}
+		default:
+			k, _, ok := strings.Cut(line, ":")
+			if !ok || !IsValidFrontmatterKey(strings.TrimSpace(k)) {
+				return false
+			}
+			hasKey = true
+		}
+	}
+	return true
+}
+
+// ParseFrontmatter returns the frontmatter of the note content and the rest
+// of the note. ok is false if the note has no frontmatter
+func ParseFrontmatter(content string) (Frontmatter, string, bool) {
+	fm, body, ok := splitFrontmatter(content)
+	if !ok {
+		return Frontmatter{}, content, false
+	}
+	if fm == "" {
+		return Frontmatter{}, body, true
+	}
+	return Frontmatter{lines: strings.Split(fm, "\n")}, body, true
+}
+
+// IsValidFrontmatterKey reports if key can be used as a frontmatter key
+func IsValidFrontmatterKey(key string) bool {
+	return frontmatterKeyRegex.MatchString(key)
+}
+
+// field returns the index of the line of key and the number
+// of lines its value spans
+func (f Frontmatter) field(key string) (int, int) {
+	for i, line := range f.lines {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+		k, _, ok := strings.Cut(line, ":")
+		if !ok || strings.TrimSpace(k) != key {
+			continue
+		}
+
+		n := 1
+		for i+n < len(f.lines) {
+			next := f.lines[i+n]
+			if !strings.HasPrefix(next, " ") && !strings.HasPrefix(next, "\t") && !strings.HasPrefix(next, "-") {
+				break
+			}
+			n++
+		}
+		return i, n
+	}
+	return -1, 0
+}
+
+// Keys returns the keys of the frontmatter, in order
+func (f Frontmatter) Keys() []string {
+	keys := []string{}
+	for _, line := range f.lines {
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if k, _, ok := strings.Cut(line, ":"); ok && IsValidFrontmatterKey(strings.TrimSpace(k)) {
+			keys = append(keys, strings.TrimSpace(k))
+		}
+	}
+	return keys
+}
+
+// Get returns the value of key. Lists are returned comma separated
+func (f Frontmatter) Get(key string) (string, bool) {
+	i, n := f.field(key)
+	if i < 0 {
+		return "", false
+	}
+
+	_, v, _ := strings.Cut(f.lines[i], ":")
+	v = strings.TrimSpace(v)
+	if n == 1 && !strings.HasPrefix(v, "[") {
+		return unquote(v), true
+	}
+	return strings.Join(f.List(key), ", "), true
+}
+
+// List returns the values of key as a list
+func (f Frontmatter) List(key string) []string {
+	values := []string{}
+	i, n := f.field(key)
+	if i < 0 {
+		return values
+	}
+
+	_, v, _ := strings.Cut(f.lines[i], ":")
+	v = strings.TrimSpace(v)
+	if v != "" {
+		v = strings.TrimSuffix(strings.TrimPrefix(v, "["), "]")
+		for _, item := range strings.Split(v, ",") {
+			if item = unquote(strings.TrimSpace(item)); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	}
+
+	// block list
+	for _, line := range f.lines[i+1 : i+n] {
+		item, ok := strings.CutPrefix(strings.TrimSpace(line), "- ")
+		if !ok {
+			continue
+		}
+		if item = unquote(strings.TrimSpace(item)); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// Set sets key to value, adding it at the end if it does not exist.
+// The tags field is written as an inline list of the comma separated values
+func (f *Frontmatter) Set(key string, value string) {
+	line := key + ": " + quote(value)
+	if key == "tags" {
+		tags := []string{}
+		for _, t := range strings.Split(value, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				tags = append(tags, quote(t))
+			}
+		}
+		line = key + ": [" + strings.Join(tags, ", ") + "]"
+	}
+
+	i, n := f.field(key)
+	if i < 0 {
+		f.lines = append(f.lines, line)
+		return
+	}
+
+	lines := append([]string{}, f.lines[:i]...)
+	lines = append(lines, line)
+	f.lines = append(lines, f.lines[i+n:]...)
+}
+
+// String returns the frontmatter block, delimiters included
+func (f Frontmatter) String() string {
+	var sb strings.Builder
+	sb.WriteString(frontmatterDelimiter + "\n")
+	for _, l := range f.lines {
+		sb.WriteString(l + "\n")
+	}
+	sb.WriteString(frontmatterDelimiter + "\n")
+	return sb.String()
+}
+
+// quote quotes a YAML scalar if it could be misread
+func quote(s string) string {
+	if s == "" || strings.ContainsAny(s, "#:[]{},\"'") || strings.TrimSpace(s) != s {
+		return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+	}
+	return s
+}
+
+// unquote removes the quotes around a YAML scalar, if any
+func unquote(s string) string {
+	if len(s) < 2 || s[len(s)-1] != s[0] {
+		return s
+	}
+	switch s[0] {
+	case '"':
+		return strings.ReplaceAll(s[1:len(s)-1], `\"`, `"`)
+	case '\'':
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,78 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFrontmatter(t *testing.T) {
+	content := "---\nstatus: draft\ntags:\n  - perf\n  - db\nowner: \"Jane: dev\"\n---\n# Body\n"
+
+	fm, body, ok := ParseFrontmatter(content)
+	assert.True(t, ok)
+	assert.Equal(t, "# Body\n", body)
+	assert.Equal(t, []string{"status", "tags", "owner"}, fm.Keys())
+
+	status, ok := fm.Get("status")
+	assert.True(t, ok)
+	assert.Equal(t, "draft", status)
+
+	tags, _ := fm.Get("tags")
+	assert.Equal(t, "perf, db", tags)
+
+	owner, _ := fm.Get("owner")
+	assert.Equal(t, "Jane: dev", owner)
+
+	_, ok = fm.Get("ticket")
+	assert.False(t, ok)
+
+	_, body, ok = ParseFrontmatter("# no frontmatter\n---\n")
+	assert.False(t, ok)
+	assert.Equal(t, "# no frontmatter\n---\n", body)
+
+	// a note may start with a horizontal rule
+	content = "---\nSome text: with a colon.\n\nMore text\n---\n# Body\n"
+	_, body, ok = ParseFrontmatter(content)
+	assert.False(t, ok)
+	assert.Equal(t, content, body)
+
+	_, body, ok = ParseFrontmatter("---\n---\n# Body\n")
+	assert.True(t, ok)
+	assert.Equal(t, "# Body\n", body)
+}
+
+func TestFrontmatterSet(t *testing.T) {
+	fm, body, _ := ParseFrontmatter("---\nstatus: draft\ntags:\n  - perf\nowner: me\n---\nbody")
+
+	fm.Set("tags", "perf, api")
+	fm.Set("status", "in-progress")
+	fm.Set("ticket", "BILL-1")
+
+	assert.Equal(t, "---\nstatus: in-progress\ntags: [perf, api]\nowner: me\nticket: BILL-1\n---\n", fm.String())
+	assert.Equal(t, "body", body)
+}
+
+func TestSetMeta(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "main"
+	writeTestNote(t, gn, "billing", "main", "# Notes\n")
+
+	err := gn.SetMeta("status", "blocked")
+	assert.NoError(t, err)
+
+	value, err := gn.GetMeta("status")
+	assert.NoError(t, err)
+	assert.Equal(t, "blocked", value)
+
+	_, err = gn.GetMeta("updated")
+	assert.NoError(t, err)
+
+	fm, body, err := gn.ReadNoteParts()
+	assert.NoError(t, err)
+	assert.Equal(t, "# Notes\n", body)
+	assert.Equal(t, []string{"status", "updated"}, fm.Keys())
+
+	assert.Error(t, gn.SetMeta("bad key", "value"))
+}
@@ -2,10 +2,12 @@ package gn
 
 import (
 	"os/exec"
+	"sort"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/mcbattirola/gitnotes/pkg/errflags"
 )
 
@@ -73,3 +75,170 @@ func getProjectRoot() (string, error) {
 	s := strings.Split(strings.TrimSpace(string(path)), "/")
 	return s[len(s)-1], nil
 }
+
+// listBranchNames returns the names of the local branches and of the
+// remote-tracking branches of r, without the remote name.
+// A branch origin/feat is returned as feat
+func listBranchNames(r *git.Repository) (map[string]bool, error) {
+	refs, err := r.References()
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	err = refs.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name()
+		switch {
+		case name.IsBranch():
+			names[name.Short()] = true
+		case name.IsRemote():
+			// short name is <remote>/<branch>
+			_, branch, ok := strings.Cut(name.Short(), "/")
+			if ok && branch != "HEAD" {
+				names[branch] = true
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
+
+// resolveBranch returns the reference of the local branch name or,
+// if there is none, of the remote-tracking branch origin/name
+func resolveBranch(r *git.Repository, name string) (*plumbing.Reference, error) {
+	ref, err := r.Reference(plumbing.NewBranchReferenceName(name), true)
+	if err == nil {
+		return ref, nil
+	}
+	if err != plumbing.ErrReferenceNotFound {
+		return nil, err
+	}
+
+	return r.Reference(plumbing.NewRemoteReferenceName("origin", name), true)
+}
+
+// findDefaultBranch returns the name of the default branch of r.
+// It is the branch origin/HEAD points to or, if that is not set,
+// main or master, whichever exists
+func findDefaultBranch(r *git.Repository) (string, error) {
+	head, err := r.Reference(plumbing.NewRemoteHEADReferenceName("origin"), false)
+	if err == nil && head.Type() == plumbing.SymbolicReference {
+		if _, branch, ok := strings.Cut(head.Target().Short(), "/"); ok {
+			return branch, nil
+		}
+	}
+
+	for _, name := range []string{"main", "master"} {
+		if _, err := resolveBranch(r, name); err == nil {
+			return name, nil
+		}
+	}
+
+	return "", errflags.New("couldn't find the default branch", errflags.NotFound)
+}
+
+// findMergeCommit returns the commit of the first-parent history of base
+// that merged tip into it. If tip was fast-forwarded, it is tip itself.
+// It returns nil if tip is not reachable from base
+func findMergeCommit(tip *object.Commit, base *object.Commit) (*object.Commit, error) {
+	// first-parent history of base, newest first
+	chain := []*object.Commit{base}
+	for c := base; c.NumParents() > 0; {
+		p, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, p)
+		c = p
+	}
+
+	isMerged := func(i int) (bool, error) {
+		if chain[i].Hash == tip.Hash {
+			return true, nil
+		}
+		return tip.IsAncestor(chain[i])
+	}
+
+	merged, err := isMerged(0)
+	if err != nil || !merged {
+		return nil, err
+	}
+
+	// once a commit of the chain no longer contains tip, none of the
+	// older ones do. Search for the oldest commit that still contains it
+	lo, hi := 0, len(chain)-1
+	for lo < hi {
+		mid := (lo + hi + 1) / 2
+		merged, err := isMerged(mid)
+		if err != nil {
+			return nil, err
+		}
+		if merged {
+			lo = mid
+		} else {
+			hi = mid - 1
+		}
+	}
+
+	return chain[lo], nil
+}
+
+// findUpstream returns the reference of the upstream of branch in r,
+// or an empty name if branch has no upstream configured
+func findUpstream(r *git.Repository, branch string) (plumbing.ReferenceName, error) {
+	cfg, err := r.Config()
+	if err != nil {
+		return "", err
+	}
+
+	b, ok := cfg.Branches[branch]
+	if !ok || b.Remote == "" || b.Merge == "" {
+		return "", nil
+	}
+	// a remote of "." means the upstream is a local branch
+	if b.Remote == "." {
+		return b.Merge, nil
+	}
+	return plumbing.NewRemoteReferenceName(b.Remote, b.Merge.Short()), nil
+}
+
+// revList returns the commits reachable from from but not from not,
+// newest first, like `git rev-list from ^not`. A zero not excludes nothing
+func revList(r *git.Repository, from plumbing.Hash, not plumbing.Hash) ([]*object.Commit, error) {
+	excluded := map[plumbing.Hash]bool{}
+	if !not.IsZero() {
+		c, err := r.CommitObject(not)
+		if err != nil {
+			return nil, err
+		}
+		err = object.NewCommitPreorderIter(c, nil, nil).ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := r.CommitObject(from)
+	if err != nil {
+		return nil, err
+	}
+	commits := []*object.Commit{}
+	err = object.NewCommitPreorderIter(c, excluded, nil).ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Committer.When.After(commits[j].Committer.When)
+	})
+	return commits, nil
+}
@@ -5,7 +5,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,8 +27,7 @@ func TestGetProjectRoot(t *testing.T) {
 	assert.Nil(t, err)
 
 	// change working directory to rootPath
-	err = os.Chdir(rootPath)
-	assert.Nil(t, err)
+	chdir(t, rootPath)
 
 	// expect the value returned to be projName
 	r, err := getProjectRoot()
@@ -72,3 +76,144 @@ func TestGetAuthorFromGitConfig(t *testing.T) {
 		})
 	}
 }
+
+// testRepo is a working repository used by tests
+type testRepo struct {
+	t    *testing.T
+	dir  string
+	repo *git.Repository
+}
+
+// newTestRepo creates a repository with an initial commit on main
+// and changes the working directory into it until the test ends
+func newTestRepo(t *testing.T) *testRepo {
+	dir := t.TempDir()
+	r, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+
+	// make main the default branch regardless of the git version
+	err = r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main")))
+	assert.NoError(t, err)
+
+	chdir(t, dir)
+
+	tr := &testRepo{t: t, dir: dir, repo: r}
+	tr.commitFile("README.md", "readme\n", "Initial commit")
+	return tr
+}
+
+// chdir changes the working directory to dir until the test ends.
+// dir must be created before, so that it is removed after changing back
+func chdir(t *testing.T, dir string) {
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		assert.NoError(t, os.Chdir(wd))
+	})
+}
+
+// commitFile writes content into name and commits it on the current branch
+func (tr *testRepo) commitFile(name string, content string, msg string) plumbing.Hash {
+	err := os.MkdirAll(filepath.Dir(filepath.Join(tr.dir, name)), os.ModeDir|0700)
+	assert.NoError(tr.t, err)
+	err = os.WriteFile(filepath.Join(tr.dir, name), []byte(content), 0644)
+	assert.NoError(tr.t, err)
+
+	w, err := tr.repo.Worktree()
+	assert.NoError(tr.t, err)
+	_, err = w.Add(name)
+	assert.NoError(tr.t, err)
+	h, err := w.Commit(msg, &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	assert.NoError(tr.t, err)
+	return h
+}
+
+// checkout switches to branch, creating it if create is true
+func (tr *testRepo) checkout(branch string, create bool) {
+	w, err := tr.repo.Worktree()
+	assert.NoError(tr.t, err)
+	err = w.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName(branch), Create: create})
+	assert.NoError(tr.t, err)
+}
+
+// merge creates a merge commit of branch into the current branch
+func (tr *testRepo) merge(branch string) plumbing.Hash {
+	head, err := tr.repo.Head()
+	assert.NoError(tr.t, err)
+	ref, err := tr.repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	assert.NoError(tr.t, err)
+
+	w, err := tr.repo.Worktree()
+	assert.NoError(tr.t, err)
+	h, err := w.Commit("Merge branch "+branch, &git.CommitOptions{
+		Author:  &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		Parents: []plumbing.Hash{head.Hash(), ref.Hash()},
+	})
+	assert.NoError(tr.t, err)
+	return h
+}
+
+func TestFindMergeCommit(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat", true)
+	tip := tr.commitFile("feat.txt", "feat\n", "Add feat")
+	tr.checkout("main", false)
+	tr.commitFile("main.txt", "main\n", "Change main")
+	mergeHash := tr.merge("feat")
+	tr.commitFile("after.txt", "after\n", "After merge")
+	head, err := tr.repo.Head()
+	assert.NoError(t, err)
+
+	base, err := tr.repo.CommitObject(head.Hash())
+	assert.NoError(t, err)
+	tipCommit, err := tr.repo.CommitObject(tip)
+	assert.NoError(t, err)
+
+	merge, err := findMergeCommit(tipCommit, base)
+	assert.NoError(t, err)
+	assert.NotNil(t, merge)
+	assert.Equal(t, mergeHash, merge.Hash)
+
+	// a branch that was not merged
+	tr.checkout("unmerged", true)
+	unmerged := tr.commitFile("unmerged.txt", "unmerged\n", "Add unmerged")
+	unmergedCommit, err := tr.repo.CommitObject(unmerged)
+	assert.NoError(t, err)
+
+	merge, err = findMergeCommit(unmergedCommit, base)
+	assert.NoError(t, err)
+	assert.Nil(t, merge)
+}
+
+// setUpstream sets the upstream of branch to remote/merge
+func (tr *testRepo) setUpstream(branch string, remote string, merge string) {
+	cfg, err := tr.repo.Config()
+	assert.NoError(tr.t, err)
+	cfg.Branches[branch] = &config.Branch{
+		Name:   branch,
+		Remote: remote,
+		Merge:  plumbing.NewBranchReferenceName(merge),
+	}
+	assert.NoError(tr.t, tr.repo.SetConfig(cfg))
+}
+
+func TestFindUpstream(t *testing.T) {
+	tr := newTestRepo(t)
+
+	upstream, err := findUpstream(tr.repo, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, plumbing.ReferenceName(""), upstream)
+
+	tr.setUpstream("main", "origin", "main")
+	upstream, err = findUpstream(tr.repo, "main")
+	assert.NoError(t, err)
+	assert.Equal(t, plumbing.ReferenceName("refs/remotes/origin/main"), upstream)
+
+	tr.setUpstream("feat/login", ".", "main")
+	upstream, err = findUpstream(tr.repo, "feat/login")
+	assert.NoError(t, err)
+	assert.Equal(t, plumbing.NewBranchReferenceName("main"), upstream)
+}
@@ -32,8 +32,38 @@ type GN struct {
 	AlwaysCommit bool
 	// RemoteURL is the URL to the remote repository
 	RemoteURL string
-	author    Author
-	log       log.Logger
+	// PruneKeep holds, by project, the branch patterns whose notes are never pruned.
+	// Patterns under the empty project apply to all projects
+	PruneKeep map[string][]string
+	// DefaultBranch is the branch other branches are merged into.
+	// If empty, it is detected from the working repository
+	DefaultBranch string
+	// ArchiveMergedAuto indicates if notes of merged branches should be archived
+	// after `gn edit` and `gn pull` on the default branch
+	ArchiveMergedAuto bool
+	// Frontmatter indicates if new notes should start with a frontmatter
+	Frontmatter bool
+	// CommitMsgSection is the note section `gn commit-msg` drafts commit messages from
+	CommitMsgSection string
+	// CommitMsgTrailer indicates if `gn commit-msg` should add a Gitnotes-Ref
+	// trailer with the revision of the notes to commit messages
+	CommitMsgTrailer bool
+	// PRTemplate is the path of the template of `gn pr-body`.
+	// If empty, a default template is used
+	PRTemplate string
+	// BranchDescSection is the note section `gn branch-desc` syncs with the branch description
+	BranchDescSection string
+	// Statuses are the statuses a note can have, in the order of their lifecycle
+	Statuses []string
+	// TrackTime indicates if the time notes are open in the editor should be logged
+	TrackTime bool
+	// TemplatesPath is the directory of the templates of new notes.
+	// If empty, the .templates directory of the notes path is used
+	TemplatesPath string
+	// RollupSections are the note sections `gn rollup` adds to the project changelog
+	RollupSections []string
+	author         Author
+	log            log.Logger
 }
 
 // New creates a new GN
@@ -83,7 +113,12 @@ func (gn *GN) Edit() error {
 		return err
 	}
 
-	return gn.edit(project, branch)
+	if err := gn.edit(project, branch); err != nil {
+		return err
+	}
+
+	gn.autoArchive()
+	return nil
 }
 
 // findProject returns the name of the project
@@ -111,14 +146,8 @@ func (gn *GN) findBranch() (string, error) {
 	// if didn't received branch name, use current working branch
 	if branch == "" {
 		// get user working repo
-		dir, err := os.Getwd()
+		r, err := gn.openWorkingRepo()
 		if err != nil {
-			gn.log.Debug("could not find branch: %s", err.Error())
-			return "", err
-		}
-		r, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
-		if err != nil {
-			gn.log.Debug("could not open repository to look for branch: %s", err.Error())
 			return "", err
 		}
 
@@ -133,6 +162,38 @@ func (gn *GN) findBranch() (string, error) {
 	return branch, nil
 }
 
+// openWorkingRepo opens the git repository of the current
+// working directory, which is the user's project
+func (gn *GN) openWorkingRepo() (*git.Repository, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		gn.log.Debug("could not get working directory: %s", err.Error())
+		return nil, err
+	}
+	r, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		gn.log.Debug("could not open working repository: %s", err.Error())
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// defaultBranch returns the configured default branch or,
+// if none is set, the one detected in the working repository r
+func (gn *GN) defaultBranch(r *git.Repository) (string, error) {
+	if gn.DefaultBranch != "" {
+		return gn.DefaultBranch, nil
+	}
+
+	branch, err := findDefaultBranch(r)
+	if err != nil {
+		return "", err
+	}
+	gn.log.Debug("default branch: %s", branch)
+	return branch, nil
+}
+
 // edit opens a specific project/branch on the selected editor
 // If project is empty, uses current project
 func (gn *GN) edit(project string, branch string) error {
@@ -154,7 +215,17 @@ func (gn *GN) edit(project string, branch string) error {
 	}
 
 	gn.log.Debug("opening note file %s", notePath)
-	_, err = os.OpenFile(notePath, os.O_APPEND|os.O_CREATE, 0644)
+	before, err := os.ReadFile(notePath)
+	if os.IsNotExist(err) {
+		// new notes start with their template and frontmatter, if any
+		var content string
+		content, err = gn.newNoteContent(NoteRef{Project: project, Branch: branch})
+		if err != nil {
+			return err
+		}
+		before = []byte(content)
+		err = os.WriteFile(notePath, before, 0644)
+	}
 	if err != nil {
 		return err
 	}
@@ -169,12 +240,19 @@ func (gn *GN) edit(project string, branch string) error {
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
+	start := time.Now()
 	err = cmd.Run()
+	if gn.TrackTime {
+		session := Session{NoteRef: NoteRef{Project: project, Branch: branch}, Kind: SessionEdit, Start: start, End: time.Now()}
+		if err := gn.logSessions(session); err != nil {
+			gn.log.Info("failed to log edit session: %s", err.Error())
+		}
+	}
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return gn.touchNote(notePath, before)
 }
 
 // createNotesPath creates the note file if it doesn't exist
@@ -233,14 +311,21 @@ func (gn *GN) Push() error {
 		return err
 	}
 
-	// create commit
-	if gn.CommitMessage == "" {
-		gn.CommitMessage = fmt.Sprintf("Update notes - %s", time.Now().Local().String())
-	}
-	err = gn.commit(gn.CommitMessage, w)
+	// create commit, if anything changed
+	status, err := w.Status()
 	if err != nil {
 		return err
 	}
+	if status.IsClean() {
+		gn.log.Debug("nothing to commit before pushing")
+	} else {
+		if gn.CommitMessage == "" {
+			gn.CommitMessage = fmt.Sprintf("Update notes - %s", time.Now().Local().String())
+		}
+		if err := gn.commit(gn.CommitMessage, w); err != nil {
+			return err
+		}
+	}
 
 	if err = gn.checkAndAddOrigin(r); err != nil {
 		return err
@@ -279,10 +364,11 @@ func (gn *GN) Pull() error {
 		return err
 	}
 	err = w.Pull(&git.PullOptions{RemoteName: "origin", ReferenceName: plumbing.Master})
-	if err != git.NoErrAlreadyUpToDate {
+	if err != nil && err != git.NoErrAlreadyUpToDate {
 		return err
 	}
 
+	gn.autoArchive()
 	return nil
 }
 
@@ -392,21 +478,22 @@ func (gn *GN) ReadNote() (string, error) {
 	return string(f), nil
 }
 
-func (gn *GN) Delete() error {
+// Delete moves the note into the trash, from where
+// it can be restored with Restore
+func (gn *GN) Delete() (TrashItem, error) {
 	var err error
 
 	project, err := gn.findProject()
 	if err != nil {
-		return err
+		return TrashItem{}, err
 	}
 
 	branch, err := gn.findBranch()
 	if err != nil {
-		return err
+		return TrashItem{}, err
 	}
 
-	notePath := getNotePath(gn.NotesPath, project, branch)
-	return os.Remove(notePath)
+	return gn.trash(NoteRef{Project: project, Branch: branch})
 }
 
 // getNotePath returns the path on the filesystem of the note
@@ -3,8 +3,11 @@ package gn
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,3 +110,25 @@ func TestReadNote(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, content, noteContent)
 }
+
+func TestPushOnlyCommitsChanges(t *testing.T) {
+	gn := newTestGN(t)
+	gn.RemoteURL = filepath.Join(t.TempDir(), "remote.git")
+	_, err := git.PlainInit(gn.RemoteURL, true)
+	assert.NoError(t, err)
+	writeTestNote(t, gn, "billing", "main", "# Billing\n")
+
+	assert.NoError(t, gn.Push())
+	assert.NoError(t, gn.Push())
+
+	r, err := git.PlainOpen(gn.NotesPath)
+	assert.NoError(t, err)
+	iter, err := r.Log(&git.LogOptions{})
+	assert.NoError(t, err)
+	count := 0
+	assert.NoError(t, iter.ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	}))
+	assert.Equal(t, 1, count)
+}
@@ -0,0 +1,144 @@
+package gn
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Node and edge types of the notes graph
+const (
+	GraphProject = "project"
+	GraphNote    = "note"
+
+	GraphContains = "contains"
+	GraphLink     = "link"
+	GraphTicket   = "ticket"
+)
+
+// Graph is the graph of projects, notes and the relations between them
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// GraphNode is a project or a note
+type GraphNode struct {
+	// ID is the project name or the project/branch of the note
+	ID       string     `json:"id"`
+	Type     string     `json:"type"`
+	Project  string     `json:"project"`
+	Branch   string     `json:"branch,omitempty"`
+	Tags     []string   `json:"tags,omitempty"`
+	Tickets  []string   `json:"tickets,omitempty"`
+	Modified *time.Time `json:"modified,omitempty"`
+}
+
+// GraphEdge relates two nodes. Contains edges go from a project to its notes,
+// link edges from a note to the note or project it links to, and ticket
+// edges relate notes that mention the same ticket key, given as the label
+type GraphEdge struct {
+	From  string `json:"from"`
+	To    string `json:"to"`
+	Type  string `json:"type"`
+	Label string `json:"label,omitempty"`
+}
+
+// Graph builds the graph of the notes selected by filter
+func (gn *GN) Graph(filter NoteFilter) (Graph, error) {
+	filter.Modified = true
+	notes, err := gn.loadNotes(filter)
+	if err != nil {
+		return Graph{}, err
+	}
+
+	g := Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	nodes := map[string]bool{}
+	tickets := map[string][]string{}
+	for _, n := range notes {
+		if !nodes[n.Project] {
+			nodes[n.Project] = true
+			g.Nodes = append(g.Nodes, GraphNode{ID: n.Project, Type: GraphProject, Project: n.Project})
+		}
+
+		id := n.String()
+		modified := n.Modified
+		node := GraphNode{
+			ID:       id,
+			Type:     GraphNote,
+			Project:  n.Project,
+			Branch:   n.Branch,
+			Tags:     n.Tags,
+			Tickets:  extractTickets(n.NoteRef, n.Content),
+			Modified: &modified,
+		}
+		nodes[id] = true
+		g.Nodes = append(g.Nodes, node)
+		g.Edges = append(g.Edges, GraphEdge{From: n.Project, To: id, Type: GraphContains})
+
+		for _, t := range node.Tickets {
+			tickets[t] = append(tickets[t], id)
+		}
+	}
+
+	// links are added once all nodes are known, so that links
+	// to notes left out by the filter are not added
+	seen := map[GraphEdge]bool{}
+	for _, n := range notes {
+		for _, l := range parseLinks(n.NoteRef, n.Content) {
+			e := GraphEdge{From: n.String(), To: l.Target.String(), Type: GraphLink}
+			if !nodes[e.To] || seen[e] || e.From == e.To {
+				continue
+			}
+			seen[e] = true
+			g.Edges = append(g.Edges, e)
+		}
+	}
+
+	keys := make([]string, 0, len(tickets))
+	for t := range tickets {
+		keys = append(keys, t)
+	}
+	sort.Strings(keys)
+	for _, t := range keys {
+		ids := tickets[t]
+		for i := 0; i < len(ids); i++ {
+			for j := i + 1; j < len(ids); j++ {
+				g.Edges = append(g.Edges, GraphEdge{From: ids[i], To: ids[j], Type: GraphTicket, Label: t})
+			}
+		}
+	}
+
+	return g, nil
+}
+
+// WriteDOT writes the graph in the Graphviz DOT language
+func (g Graph) WriteDOT(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString("digraph gitnotes {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	for _, n := range g.Nodes {
+		if n.Type == GraphProject {
+			sb.WriteString(fmt.Sprintf("  %s [shape=box, style=bold];\n", strconv.Quote(n.ID)))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("  %s [label=%s];\n", strconv.Quote(n.ID), strconv.Quote(n.Branch)))
+	}
+	for _, e := range g.Edges {
+		attrs := ""
+		switch e.Type {
+		case GraphLink:
+			attrs = " [style=dashed]"
+		case GraphTicket:
+			attrs = fmt.Sprintf(" [dir=none, style=dotted, label=%s]", strconv.Quote(e.Label))
+		}
+		sb.WriteString(fmt.Sprintf("  %s -> %s%s;\n", strconv.Quote(e.From), strconv.Quote(e.To), attrs))
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
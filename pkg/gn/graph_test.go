@@ -0,0 +1,37 @@
+package gn
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "feat/bill-12-refunds", "see [[billing/main]] and [[api]]")
+	writeTestNote(t, gn, "billing", "main", "main notes")
+	writeTestNote(t, gn, "api", "main", "api side of BILL-12, see [[missing/main]]")
+
+	g, err := gn.Graph(NoteFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, g.Nodes, 5)
+
+	assert.Contains(t, g.Edges, GraphEdge{From: "billing", To: "billing/main", Type: GraphContains})
+	assert.Contains(t, g.Edges, GraphEdge{From: "billing/feat/bill-12-refunds", To: "billing/main", Type: GraphLink})
+	assert.Contains(t, g.Edges, GraphEdge{From: "billing/feat/bill-12-refunds", To: "api", Type: GraphLink})
+	assert.Contains(t, g.Edges, GraphEdge{From: "api/main", To: "billing/feat/bill-12-refunds", Type: GraphTicket, Label: "BILL-12"})
+	assert.Len(t, g.Edges, 6, "broken links are not edges")
+
+	// links to notes left out by the filter are not edges
+	g, err = gn.Graph(NoteFilter{Project: "billing"})
+	assert.NoError(t, err)
+	assert.Len(t, g.Nodes, 3)
+	assert.Len(t, g.Edges, 3)
+
+	var buf bytes.Buffer
+	err = g.WriteDOT(&buf)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), `"billing" [shape=box, style=bold];`)
+	assert.Contains(t, buf.String(), `"billing/feat/bill-12-refunds" -> "billing/main" [style=dashed];`)
+}
@@ -0,0 +1,255 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// Hooks are the git hooks `gn hooks install` manages
+var Hooks = []string{"post-checkout", "prepare-commit-msg", "post-commit", "pre-push"}
+
+const (
+	// hookBegin and hookEnd mark the block gn manages inside a hook script
+	hookBegin = "# >>> gitnotes >>>"
+	hookEnd   = "# <<< gitnotes <<<"
+	// chainedHookSuffix is added to existing hooks, which are moved
+	// aside and called by the script gn writes in their place
+	chainedHookSuffix = ".pre-gitnotes"
+)
+
+// HookChange is a hook changed by InstallHooks or UninstallHooks
+type HookChange struct {
+	Name string
+	Path string
+	// Chained indicates if the hook existed before gn and is still called
+	Chained bool
+}
+
+// hookBlock returns the block of the hook script that calls gn. The hook
+// it was chained with runs first, and gn only runs if that one succeeds
+func hookBlock(name string) string {
+	return hookBegin + "\n" +
+		"# added by `gn hooks install`, remove with `gn hooks uninstall`\n" +
+		"chained=\"$(dirname \"$0\")/" + name + chainedHookSuffix + "\"\n" +
+		"if [ -x \"$chained\" ]; then\n" +
+		"\t\"$chained\" \"$@\" || exit $?\n" +
+		"fi\n" +
+		"if command -v gn >/dev/null 2>&1; then\n" +
+		"\tgn hooks run " + name + " \"$@\"\n" +
+		"fi\n" +
+		hookEnd + "\n"
+}
+
+// InstallHooks adds the gn hooks to the working repository, in the hooks
+// directory git uses, which may be set by core.hooksPath. Existing hooks are
+// moved to <hook>.pre-gitnotes and called before gn, which only runs if they
+// succeed. Installing twice updates the gn blocks in place
+func (gn *GN) InstallHooks() ([]HookChange, error) {
+	dir, err := gn.hooksDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, os.ModeDir|0755); err != nil {
+		return nil, err
+	}
+
+	changes := []HookChange{}
+	for _, name := range Hooks {
+		path := filepath.Join(dir, name)
+		change := HookChange{Name: name, Path: path}
+		_, err := os.Stat(path + chainedHookSuffix)
+		change.Chained = err == nil
+
+		content, err := os.ReadFile(path)
+		switch {
+		case os.IsNotExist(err):
+			content = []byte("#!/bin/sh\n")
+		case err != nil:
+			return nil, err
+		case !strings.Contains(string(content), hookBegin):
+			if change.Chained {
+				return nil, errflags.New(fmt.Sprintf("cannot chain %s: %s already exists", path, path+chainedHookSuffix), errflags.BadParameter)
+			}
+			gn.log.Debug("moving %s aside to chain it", path)
+			if err := os.Rename(path, path+chainedHookSuffix); err != nil {
+				return nil, err
+			}
+			content = []byte("#!/bin/sh\n")
+			change.Chained = true
+		}
+
+		if err := os.WriteFile(path, []byte(addHookBlock(string(content), hookBlock(name))), 0755); err != nil {
+			return nil, err
+		}
+		// WriteFile keeps the mode of existing files
+		if err := os.Chmod(path, 0755); err != nil {
+			return nil, err
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// UninstallHooks removes the gn hooks from the working repository,
+// restoring the hooks they were chained with. Hooks only gn used are deleted
+func (gn *GN) UninstallHooks() ([]HookChange, error) {
+	dir, err := gn.hooksDir()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []HookChange{}
+	for _, name := range Hooks {
+		path := filepath.Join(dir, name)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !strings.Contains(string(content), hookBegin) {
+			continue
+		}
+		change := HookChange{Name: name, Path: path}
+
+		if _, err := os.Stat(path + chainedHookSuffix); err == nil {
+			change.Chained = true
+			if err := os.Rename(path+chainedHookSuffix, path); err != nil {
+				return nil, err
+			}
+		} else if rest := removeHookBlock(string(content)); strings.TrimSpace(rest) == "#!/bin/sh" {
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+		} else {
+			change.Chained = true
+			if err := os.WriteFile(path, []byte(rest), 0755); err != nil {
+				return nil, err
+			}
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// hooksDir returns the hooks directory of the working repository,
+// as git resolves it: core.hooksPath or the hooks directory of .git
+func (gn *GN) hooksDir() (string, error) {
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return "", errflags.Flag(fmt.Errorf("not in a git repository: %w", err), errflags.BadParameter)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		return "", err
+	}
+	root := w.Filesystem.Root()
+
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = root
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git rev-parse: %s", strings.TrimSpace(string(out)))
+	}
+	dir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(root, dir)
+	}
+	gn.log.Debug("hooks directory: %s", dir)
+	return dir, nil
+}
+
+// addHookBlock adds block at the end of a hook script,
+// replacing the block a previous install added
+func addHookBlock(content string, block string) string {
+	content = removeHookBlock(content)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return content + block
+}
+
+// removeHookBlock removes the gn block from a hook script
+func removeHookBlock(content string) string {
+	start := strings.Index(content, hookBegin)
+	if start < 0 {
+		return content
+	}
+	end := strings.Index(content[start:], hookEnd)
+	if end < 0 {
+		return content
+	}
+	end += start + len(hookEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+	return content[:start] + content[end:]
+}
+
+// NoteSummary is a short summary of a note, printed when checking out its branch
+type NoteSummary struct {
+	Note   NoteRef
+	Title  string
+	Status string
+	// Summary is the first line of the branch description section
+	Summary   string
+	OpenTodos int
+}
+
+// String returns the summary in a few lines
+func (s NoteSummary) String() string {
+	line := "note " + s.Note.String()
+	if s.Status != "" {
+		line += " [" + s.Status + "]"
+	}
+	if s.Title != "" {
+		line += ": " + s.Title
+	}
+	lines := []string{line}
+	if s.Summary != "" {
+		lines = append(lines, "  "+s.Summary)
+	}
+	switch {
+	case s.OpenTodos == 1:
+		lines = append(lines, "  1 open todo")
+	case s.OpenTodos > 1:
+		lines = append(lines, fmt.Sprintf("  %d open todos", s.OpenTodos))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Summary returns a summary of the current note
+func (gn *GN) Summary() (NoteSummary, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return NoteSummary{}, err
+	}
+	content, err := gn.readNote(ref)
+	if err != nil {
+		return NoteSummary{}, err
+	}
+	_, body, _ := ParseFrontmatter(content)
+
+	s := NoteSummary{Note: ref, Status: noteStatus(content)}
+	for _, h := range parseHeadings(body, 1) {
+		s.Title = h.Text
+		break
+	}
+	if section, ok := extractSection(body, gn.branchDescSection()); ok {
+		s.Summary, _, _ = strings.Cut(section, "\n")
+	}
+	for _, t := range parseTodos(ref, body) {
+		if !t.Done {
+			s.OpenTodos++
+		}
+	}
+	return s, nil
+}
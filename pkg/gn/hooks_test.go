@@ -0,0 +1,132 @@
+package gn
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstallHooks(t *testing.T) {
+	tr := newTestRepo(t)
+	gn := newTestGN(t)
+	dir := filepath.Join(tr.dir, ".git", "hooks")
+
+	existing := "#!/usr/bin/env bash\nset -e\necho checked\n"
+	python := "#!/usr/bin/env python3\nprint('push')\n"
+	assert.NoError(t, os.MkdirAll(dir, os.ModeDir|0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "post-checkout"), []byte(existing), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pre-push"), []byte(python), 0755))
+
+	changes, err := gn.InstallHooks()
+	assert.NoError(t, err)
+	assert.Len(t, changes, 4)
+	for _, c := range changes {
+		assert.Equal(t, c.Name == "post-checkout" || c.Name == "pre-push", c.Chained, c.Name)
+		info, err := os.Stat(c.Path)
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	}
+
+	for _, name := range Hooks {
+		assert.Equal(t, "#!/bin/sh\n"+hookBlock(name), readHook(t, dir, name))
+	}
+	assert.Equal(t, existing, readHook(t, dir, "post-checkout"+chainedHookSuffix))
+	assert.Equal(t, python, readHook(t, dir, "pre-push"+chainedHookSuffix))
+
+	// installing again changes nothing
+	_, err = gn.InstallHooks()
+	assert.NoError(t, err)
+	for _, name := range Hooks {
+		assert.Equal(t, "#!/bin/sh\n"+hookBlock(name), readHook(t, dir, name))
+	}
+	assert.Equal(t, existing, readHook(t, dir, "post-checkout"+chainedHookSuffix))
+	assert.Equal(t, python, readHook(t, dir, "pre-push"+chainedHookSuffix))
+
+	changes, err = gn.UninstallHooks()
+	assert.NoError(t, err)
+	assert.Len(t, changes, 4)
+	assert.Equal(t, existing, readHook(t, dir, "post-checkout"))
+	assert.Equal(t, python, readHook(t, dir, "pre-push"))
+	_, err = os.Stat(filepath.Join(dir, "prepare-commit-msg"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "pre-push"+chainedHookSuffix))
+	assert.True(t, os.IsNotExist(err))
+
+	changes, err = gn.UninstallHooks()
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestInstallHooksHooksPath(t *testing.T) {
+	tr := newTestRepo(t)
+	gn := newTestGN(t)
+
+	err := exec.Command("git", "config", "core.hooksPath", ".githooks").Run()
+	assert.NoError(t, err)
+
+	changes, err := gn.InstallHooks()
+	assert.NoError(t, err)
+	for _, c := range changes {
+		assert.Equal(t, filepath.Join(tr.dir, ".githooks", c.Name), c.Path)
+	}
+	_, err = os.Stat(filepath.Join(tr.dir, ".git", "hooks", "post-checkout"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestHookRunsAfterChainedHook(t *testing.T) {
+	tr := newTestRepo(t)
+	gn := newTestGN(t)
+	dir := filepath.Join(tr.dir, ".git", "hooks")
+
+	// a fake gn logs its calls
+	bin := t.TempDir()
+	calls := filepath.Join(bin, "calls")
+	fake := "#!/bin/sh\necho \"$@\" >> " + calls + "\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(bin, "gn"), []byte(fake), 0755))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	assert.NoError(t, os.MkdirAll(dir, os.ModeDir|0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pre-push"), []byte("#!/bin/sh\nexit 1\n"), 0755))
+	_, err := gn.InstallHooks()
+	assert.NoError(t, err)
+
+	// a rejected push does not push the notes
+	err = exec.Command(filepath.Join(dir, "pre-push"), "origin", "url").Run()
+	assert.Error(t, err)
+	_, err = os.Stat(calls)
+	assert.True(t, os.IsNotExist(err))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pre-push"+chainedHookSuffix), []byte("#!/bin/sh\nexit 0\n"), 0755))
+	err = exec.Command(filepath.Join(dir, "pre-push"), "origin", "url").Run()
+	assert.NoError(t, err)
+	err = exec.Command(filepath.Join(dir, "post-checkout"), "a", "b", "1").Run()
+	assert.NoError(t, err)
+	out, err := os.ReadFile(calls)
+	assert.NoError(t, err)
+	assert.Equal(t, "hooks run pre-push origin url\nhooks run post-checkout a b 1\n", string(out))
+}
+
+func TestSummary(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/login"
+	writeTestNote(t, gn, "billing", "feat/login", "---\nstatus: in-review\n---\n# Login\n## Summary\nAdds login.\n\nMore.\n## Tasks\n- [x] form\n- [ ] tests\n- [ ] docs\n")
+
+	s, err := gn.Summary()
+	assert.NoError(t, err)
+	assert.Equal(t, "note billing/feat/login [in-review]: Login\n  Adds login.\n  2 open todos", s.String())
+
+	gn.Branch = "missing"
+	_, err = gn.Summary()
+	assert.Error(t, err)
+}
+
+// readHook returns the content of the hook name in dir
+func readHook(t *testing.T, dir string, name string) string {
+	content, err := os.ReadFile(filepath.Join(dir, name))
+	assert.NoError(t, err)
+	return string(content)
+}
@@ -0,0 +1,43 @@
+package gn
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// readKeyValues reads a file of key=value lines, like the ones
+// kept next to trashed and archived notes
+func readKeyValues(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values := map[string]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// writeKeyValues writes the pairs into path as key=value lines,
+// keeping the order in which they were given
+func writeKeyValues(path string, pairs ...[2]string) error {
+	var sb strings.Builder
+	for _, p := range pairs {
+		sb.WriteString(p[0] + "=" + p[1] + "\n")
+	}
+
+	return os.WriteFile(path, []byte(sb.String()), 0644)
+}
@@ -0,0 +1,154 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// linkRegex matches wiki-style links to other notes, like [[billing/feat-refunds]],
+// [[billing]] or [[billing/main|billing notes]]
+var linkRegex = regexp.MustCompile(`\[\[([^\[\]|]+)(\|[^\[\]]*)?\]\]`)
+
+// Link is a wiki-style link from a note to another note or project
+type Link struct {
+	From NoteRef
+	// Line is the 1-based line number of the link in From
+	Line int
+	// Target is the linked note. Links to a project have an empty branch
+	Target NoteRef
+	// Broken is true if the target does not exist
+	Broken bool
+}
+
+// parseLinkTarget parses the target of a link. Targets with a slash are
+// project/branch notes, resolved like any other note; the others are projects
+func parseLinkTarget(s string) NoteRef {
+	project, branch, _ := strings.Cut(strings.TrimSpace(s), "/")
+	return NoteRef{Project: strings.TrimSpace(project), Branch: strings.TrimSpace(branch)}
+}
+
+// parseLinks returns the links of the note content
+func parseLinks(from NoteRef, content string) []Link {
+	links := []Link{}
+	for i, line := range strings.Split(content, "\n") {
+		for _, m := range linkRegex.FindAllStringSubmatch(line, -1) {
+			target := parseLinkTarget(m[1])
+			if target.Project == "" {
+				continue
+			}
+			links = append(links, Link{From: from, Line: i + 1, Target: target})
+		}
+	}
+	return links
+}
+
+// linkExists reports if the target of a link exists. The path is built
+// the same way getNotePath builds it when editing the note
+func (gn *GN) linkExists(target NoteRef) bool {
+	if target.Branch == "" {
+		fi, err := os.Stat(filepath.Join(gn.NotesPath, target.Project))
+		return err == nil && fi.IsDir()
+	}
+
+	fi, err := os.Stat(target.path(gn.NotesPath))
+	return err == nil && !fi.IsDir()
+}
+
+// Links returns the links of the current note
+func (gn *GN) Links() ([]Link, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := gn.readNote(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	links := parseLinks(ref, content)
+	for i := range links {
+		links[i].Broken = !gn.linkExists(links[i].Target)
+	}
+	return links, nil
+}
+
+// Backlinks returns the links from other notes to the current note
+// and to its project
+func (gn *GN) Backlinks() ([]Link, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := gn.loadNotes(NoteFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	backlinks := []Link{}
+	for _, n := range notes {
+		if n.NoteRef == ref {
+			continue
+		}
+		for _, l := range parseLinks(n.NoteRef, n.Content) {
+			if l.Target == ref || (l.Target.Branch == "" && l.Target.Project == ref.Project) {
+				backlinks = append(backlinks, l)
+			}
+		}
+	}
+	return backlinks, nil
+}
+
+// BrokenLinks returns the links of the notes selected by filter
+// whose target does not exist
+func (gn *GN) BrokenLinks(filter NoteFilter) ([]Link, error) {
+	notes, err := gn.loadNotes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	broken := []Link{}
+	for _, n := range notes {
+		for _, l := range parseLinks(n.NoteRef, n.Content) {
+			if !gn.linkExists(l.Target) {
+				l.Broken = true
+				broken = append(broken, l)
+			}
+		}
+	}
+	return broken, nil
+}
+
+// rewriteLinks replaces the links to src by links to dst in every note.
+// It returns the paths, relative to the notes path, of the notes changed
+func (gn *GN) rewriteLinks(src NoteRef, dst NoteRef) ([]string, error) {
+	notes, err := gn.loadNotes(NoteFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	changed := []string{}
+	for _, n := range notes {
+		content := linkRegex.ReplaceAllStringFunc(n.Content, func(link string) string {
+			m := linkRegex.FindStringSubmatch(link)
+			if parseLinkTarget(m[1]) != src {
+				return link
+			}
+			return fmt.Sprintf("[[%s%s]]", dst, m[2])
+		})
+		if content == n.Content {
+			continue
+		}
+
+		gn.log.Debug("rewriting links to %s in %s", src, n.NoteRef)
+		if err := os.WriteFile(n.path(gn.NotesPath), []byte(content), 0644); err != nil {
+			return changed, err
+		}
+		changed = append(changed, filepath.Join(n.Project, n.Branch))
+	}
+	return changed, nil
+}
@@ -0,0 +1,54 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLinks(t *testing.T) {
+	content := "see [[billing/feat/refunds]] and [[billing]]\n[[other/main|the other note]] [[ ]]"
+	from := NoteRef{Project: "notes", Branch: "main"}
+
+	assert.Equal(t, []Link{
+		{From: from, Line: 1, Target: NoteRef{Project: "billing", Branch: "feat/refunds"}},
+		{From: from, Line: 1, Target: NoteRef{Project: "billing"}},
+		{From: from, Line: 2, Target: NoteRef{Project: "other", Branch: "main"}},
+	}, parseLinks(from, content))
+}
+
+func TestLinksAndBacklinks(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "main notes")
+	writeTestNote(t, gn, "billing", "feat/refunds", "part of [[billing/main]], see [[billing]] and [[gone/main]]")
+	writeTestNote(t, gn, "other", "main", "depends on [[billing/main|billing]]")
+
+	gn.Project = "billing"
+	gn.Branch = "feat/refunds"
+	links, err := gn.Links()
+	assert.NoError(t, err)
+	assert.Len(t, links, 3)
+	assert.False(t, links[0].Broken)
+	assert.False(t, links[1].Broken)
+	assert.True(t, links[2].Broken)
+
+	gn.Branch = "main"
+	backlinks, err := gn.Backlinks()
+	assert.NoError(t, err)
+	assert.Len(t, backlinks, 3)
+
+	broken, err := gn.BrokenLinks(NoteFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, broken, 1)
+	assert.Equal(t, NoteRef{Project: "gone", Branch: "main"}, broken[0].Target)
+}
+
+func TestMoveRewritesLinks(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "feat-refund", "refunds")
+	writeTestNote(t, gn, "other", "main", "see [[billing/feat-refund|refunds]] and [[billing/feat-refund-v2]]")
+
+	err := gn.Move(NoteRef{"billing", "feat-refund"}, NoteRef{"billing", "feat/refunds"}, ConflictFail)
+	assert.NoError(t, err)
+	assert.Equal(t, "see [[billing/feat/refunds|refunds]] and [[billing/feat-refund-v2]]", readTestNote(t, gn, "other", "main"))
+}
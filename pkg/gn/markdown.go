@@ -0,0 +1,104 @@
+package gn
+
+import (
+	"strings"
+)
+
+// parseHeading parses a Markdown ATX heading (e.g. "## Decisions")
+// and returns its level and text
+func parseHeading(line string) (int, string, bool) {
+	trimmed := strings.TrimRight(line, " \t")
+	level := 0
+	for level < len(trimmed) && trimmed[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+
+	rest := trimmed[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		// #tag is not a heading
+		return 0, "", false
+	}
+
+	text := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(rest), "#"))
+	return level, text, true
+}
+
+// scanHeadings returns the headings of lines, in order, with Line set to
+// the line number of the heading. Headings inside fenced code blocks are skipped
+func scanHeadings(lines []string) []Heading {
+	headings := []Heading{}
+	inCode := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			continue
+		}
+		if level, text, ok := parseHeading(line); ok {
+			headings = append(headings, Heading{Level: level, Text: text, Line: i + 1})
+		}
+	}
+	return headings
+}
+
+// findSection returns the index of the first line after the heading of the
+// first section of lines named name, case insensitive, and the index of the
+// line ending it: the next heading of the same or a higher level, or len(lines)
+func findSection(lines []string, name string) (int, int, bool) {
+	start, level := -1, 0
+	for _, h := range scanHeadings(lines) {
+		if start >= 0 && h.Level <= level {
+			return start, h.Line - 1, true
+		}
+		if start < 0 && strings.EqualFold(h.Text, name) {
+			start, level = h.Line, h.Level
+		}
+	}
+	if start < 0 {
+		return 0, 0, false
+	}
+	return start, len(lines), true
+}
+
+// extractSection returns the body of the first section of content whose
+// heading is name, case insensitive. The section ends at the next heading
+// of the same or a higher level. The heading itself is not returned
+func extractSection(content string, name string) (string, bool) {
+	lines := strings.Split(content, "\n")
+	start, end, ok := findSection(lines, name)
+	if !ok {
+		return "", false
+	}
+	return strings.TrimSpace(strings.Join(lines[start:end], "\n")), true
+}
+
+// replaceSection replaces the body of the first section of content whose
+// heading is name, case insensitive, with body. If there is no such section,
+// it is appended as a level 2 section
+func replaceSection(content string, name string, body string) string {
+	body = strings.TrimSpace(body)
+	lines := strings.Split(content, "\n")
+	start, end, ok := findSection(lines, name)
+	if !ok {
+		section := "## " + name + "\n\n" + body + "\n"
+		if strings.TrimSpace(content) == "" {
+			return section
+		}
+		return strings.TrimRight(content, "\n") + "\n\n" + section
+	}
+
+	replaced := append([]string{}, lines[:start]...)
+	replaced = append(replaced, "")
+	if body != "" {
+		replaced = append(replaced, body, "")
+	}
+	if end == len(lines) {
+		return strings.Join(replaced, "\n")
+	}
+	return strings.Join(append(replaced, lines[end:]...), "\n")
+}
@@ -0,0 +1,92 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeading(t *testing.T) {
+	tt := []struct {
+		line  string
+		level int
+		text  string
+		ok    bool
+	}{
+		{line: "# Title", level: 1, text: "Title", ok: true},
+		{line: "### Decisions ###", level: 3, text: "Decisions", ok: true},
+		{line: "#tag", ok: false},
+		{line: "text", ok: false},
+		{line: "####### too deep", ok: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.line, func(t *testing.T) {
+			level, text, ok := parseHeading(tc.line)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.level, level)
+			assert.Equal(t, tc.text, text)
+		})
+	}
+}
+
+func TestExtractSection(t *testing.T) {
+	content := "# Feature\nintro\n## Decisions\n- use queues\n### Details\nmore\n## Follow-ups\n- docs\n"
+
+	s, ok := extractSection(content, "decisions")
+	assert.True(t, ok)
+	assert.Equal(t, "- use queues\n### Details\nmore", s)
+
+	s, ok = extractSection(content, "Follow-ups")
+	assert.True(t, ok)
+	assert.Equal(t, "- docs", s)
+
+	_, ok = extractSection(content, "Risks")
+	assert.False(t, ok)
+
+	// comments in code blocks are not headings
+	content = "## Testing\n```sh\n# run the tests\ngo test ./...\n```\n## Notes\nx\n"
+	s, ok = extractSection(content, "Testing")
+	assert.True(t, ok)
+	assert.Equal(t, "```sh\n# run the tests\ngo test ./...\n```", s)
+}
+
+func TestReplaceSection(t *testing.T) {
+	tt := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "section in the middle",
+			content:  "# Login\n## Summary\nold\n### Details\nmore\n## Testing\nunit\n",
+			expected: "# Login\n## Summary\n\nnew body\n\n## Testing\nunit\n",
+		},
+		{
+			name:     "last section",
+			content:  "# Login\n## summary\nold\n",
+			expected: "# Login\n## summary\n\nnew body\n",
+		},
+		{
+			name:     "code block with a comment",
+			content:  "# Login\n## Summary\n```sh\n# old\nmake\n```\n## Testing\nunit\n",
+			expected: "# Login\n## Summary\n\nnew body\n\n## Testing\nunit\n",
+		},
+		{
+			name:     "missing section",
+			content:  "# Login\n",
+			expected: "# Login\n\n## Summary\n\nnew body\n",
+		},
+		{
+			name:     "empty note",
+			content:  "",
+			expected: "## Summary\n\nnew body\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, replaceSection(tc.content, "Summary", " new body\n"))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// ReadNoteParts returns the frontmatter and the body of the note.
+// The frontmatter is empty if the note has none
+func (gn *GN) ReadNoteParts() (Frontmatter, string, error) {
+	content, err := gn.ReadNote()
+	if err != nil {
+		return Frontmatter{}, "", err
+	}
+
+	fm, body, _ := ParseFrontmatter(content)
+	return fm, body, nil
+}
+
+// GetMeta returns the value of the frontmatter field key of the note
+func (gn *GN) GetMeta(key string) (string, error) {
+	fm, _, err := gn.ReadNoteParts()
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := fm.Get(key)
+	if !ok {
+		return "", errflags.New(fmt.Sprintf("field %s not found", key), errflags.NotFound)
+	}
+	return value, nil
+}
+
+// SetMeta sets the frontmatter field key of the note to value,
+// adding a frontmatter to the note if it has none.
+// The updated field is bumped as well
+func (gn *GN) SetMeta(key string, value string) error {
+	if !IsValidFrontmatterKey(key) {
+		return errflags.New("invalid field name "+key, errflags.BadParameter)
+	}
+
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return err
+	}
+
+	return gn.setMeta(ref, map[string]string{key: value}, fmt.Sprintf("Set %s of %s", key, ref))
+}
+
+// setMeta sets the frontmatter fields of the note of ref and bumps its updated
+// field. The note is commited with msg if AlwaysCommit is set
+func (gn *GN) setMeta(ref NoteRef, fields map[string]string, msg string) error {
+	if err := gn.writeMeta(ref, fields); err != nil {
+		return err
+	}
+
+	if !gn.AlwaysCommit {
+		return nil
+	}
+	return gn.commitPaths(msg, filepath.Join(ref.Project, ref.Branch))
+}
+
+// writeMeta sets the frontmatter fields of the note of ref, adding
+// a frontmatter if it has none, and bumps its updated field
+func (gn *GN) writeMeta(ref NoteRef, fields map[string]string) error {
+	notePath := ref.path(gn.NotesPath)
+	content, err := os.ReadFile(notePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	fm, body, _ := ParseFrontmatter(string(content))
+	for _, key := range sortedKeys(fields) {
+		fm.Set(key, fields[key])
+	}
+	if _, ok := fields["updated"]; !ok {
+		fm.Set("updated", formatMetaTime(time.Now()))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(notePath), os.ModeDir|0700); err != nil {
+		return err
+	}
+	return os.WriteFile(notePath, []byte(fm.String()+body), 0644)
+}
+
+// touchNote bumps the updated field of the frontmatter of the note
+// at notePath if its content is no longer before.
+// Notes without frontmatter are left as they are
+func (gn *GN) touchNote(notePath string, before []byte) error {
+	content, err := os.ReadFile(notePath)
+	if err != nil {
+		return err
+	}
+	if string(content) == string(before) {
+		return nil
+	}
+
+	fm, body, ok := ParseFrontmatter(string(content))
+	if !ok {
+		return nil
+	}
+
+	gn.log.Debug("bumping updated field of %s", notePath)
+	fm.Set("updated", formatMetaTime(time.Now()))
+	return os.WriteFile(notePath, []byte(fm.String()+body), 0644)
+}
+
+// newNoteContent returns the initial content of a new note: its template,
+// if there is one, preceded by a frontmatter if enabled and the template has none
+func (gn *GN) newNoteContent(ref NoteRef) (string, error) {
+	body, err := gn.renderTemplate(ref)
+	if err != nil {
+		return "", err
+	}
+	if !gn.Frontmatter {
+		return body, nil
+	}
+	if _, _, ok := ParseFrontmatter(body); ok {
+		return body, nil
+	}
+
+	now := formatMetaTime(time.Now())
+	var fm Frontmatter
+	fm.Set("status", "draft")
+	fm.Set("tags", "")
+	if gn.author.Name != "" {
+		fm.Set("owner", gn.author.Name)
+	}
+	if ticket := ticketFromBranch(ref.Branch); ticket != "" {
+		fm.Set("ticket", ticket)
+	}
+	fm.Set("created", now)
+	fm.Set("updated", now)
+	return fm.String() + "\n" + body, nil
+}
+
+// formatMetaTime formats a time for the frontmatter
+func formatMetaTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+// sortedKeys returns the keys of m sorted
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
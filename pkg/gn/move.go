@@ -0,0 +1,147 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// ConflictMode defines what happens when the destination
+// of a move or copy already has a note
+type ConflictMode int
+
+const (
+	// ConflictFail aborts the operation
+	ConflictFail ConflictMode = iota
+	// ConflictOverwrite replaces the destination note
+	ConflictOverwrite
+	// ConflictAppend appends the source note to the destination note
+	ConflictAppend
+	// ConflictMerge appends only the source lines that are not
+	// already in the destination note
+	ConflictMerge
+)
+
+// Move moves the note src to dst and commits the change.
+// Links to src in other notes are rewritten to point to dst.
+// Both removal and creation are commited together, so
+// `git log --follow` can track the note
+func (gn *GN) Move(src NoteRef, dst NoteRef, mode ConflictMode) error {
+	if err := gn.transfer(src, dst, mode, true); err != nil {
+		return err
+	}
+
+	paths := []string{filepath.Join(src.Project, src.Branch), filepath.Join(dst.Project, dst.Branch)}
+	changed, err := gn.rewriteLinks(src, dst)
+	if err != nil {
+		return err
+	}
+
+	return gn.commitPaths(fmt.Sprintf("Move %s to %s", src, dst), append(paths, changed...)...)
+}
+
+// Copy copies the note src to dst and commits the change
+func (gn *GN) Copy(src NoteRef, dst NoteRef, mode ConflictMode) error {
+	if err := gn.transfer(src, dst, mode, false); err != nil {
+		return err
+	}
+
+	return gn.commitPaths(fmt.Sprintf("Copy %s to %s", src, dst), filepath.Join(dst.Project, dst.Branch))
+}
+
+// transfer writes the content of src into dst according to mode.
+// If remove is true, src is deleted afterwards
+func (gn *GN) transfer(src NoteRef, dst NoteRef, mode ConflictMode, remove bool) error {
+	if src == dst {
+		return errflags.New("source and destination are the same note", errflags.BadParameter)
+	}
+
+	srcPath := src.path(gn.NotesPath)
+	dstPath := dst.path(gn.NotesPath)
+
+	content, err := os.ReadFile(srcPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errflags.Flag(fmt.Errorf("note %s not found", src), errflags.NotFound)
+		}
+		return err
+	}
+
+	content = rebaseAssetLinks(content, filepath.Dir(srcPath), filepath.Dir(dstPath))
+
+	existing, err := os.ReadFile(dstPath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	exists := err == nil
+
+	if exists {
+		switch mode {
+		case ConflictOverwrite:
+		case ConflictAppend:
+			content = appendNote(existing, content)
+		case ConflictMerge:
+			content = mergeNotes(existing, content)
+		default:
+			return errflags.New(fmt.Sprintf("note %s already exists", dst), errflags.BadParameter)
+		}
+	}
+
+	gn.log.Debug("writing %s into %s", srcPath, dstPath)
+	if err := os.MkdirAll(filepath.Dir(dstPath), os.ModeDir|0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(dstPath, content, 0644); err != nil {
+		return err
+	}
+
+	if !remove {
+		return nil
+	}
+
+	if err := os.Remove(srcPath); err != nil {
+		return err
+	}
+	removeEmptyParents(srcPath, filepath.Join(gn.NotesPath, src.Project))
+
+	return nil
+}
+
+// appendNote returns dst followed by src, separated by a newline
+func appendNote(dst []byte, src []byte) []byte {
+	if len(dst) == 0 {
+		return src
+	}
+
+	out := append([]byte{}, dst...)
+	if !strings.HasSuffix(string(out), "\n") {
+		out = append(out, '\n')
+	}
+	return append(out, src...)
+}
+
+// mergeNotes appends to dst the lines of src that dst does not contain
+func mergeNotes(dst []byte, src []byte) []byte {
+	seen := map[string]bool{}
+	for _, line := range strings.Split(string(dst), "\n") {
+		seen[line] = true
+	}
+
+	var missing []string
+	for _, line := range strings.Split(strings.TrimRight(string(src), "\n"), "\n") {
+		if line == "" || seen[line] {
+			continue
+		}
+		seen[line] = true
+		missing = append(missing, line)
+	}
+
+	if len(missing) == 0 {
+		return dst
+	}
+
+	return appendNote(dst, []byte(strings.Join(missing, "\n")+"\n"))
+}
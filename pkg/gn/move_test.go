@@ -0,0 +1,63 @@
+package gn
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMove(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "feat/refunds", "refund notes\n")
+
+	src := NoteRef{Project: "billing", Branch: "feat/refunds"}
+	dst := NoteRef{Project: "billing", Branch: "main"}
+	err := gn.Move(src, dst, ConflictFail)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "refund notes\n", readTestNote(t, gn, "billing", "main"))
+	_, err = os.Stat(getNotePath(gn.NotesPath, "billing", "feat"))
+	assert.True(t, os.IsNotExist(err), "empty branch directories should be removed")
+
+	// the move is commited
+	r, err := git.PlainOpen(gn.NotesPath)
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	status, err := w.Status()
+	assert.NoError(t, err)
+	assert.True(t, status.IsClean())
+}
+
+func TestCopyConflicts(t *testing.T) {
+	tt := []struct {
+		name      string
+		mode      ConflictMode
+		expected  string
+		expectErr bool
+	}{
+		{name: "it fails by default", mode: ConflictFail, expected: "a\nb\n", expectErr: true},
+		{name: "it overwrites", mode: ConflictOverwrite, expected: "b\nc\n"},
+		{name: "it appends", mode: ConflictAppend, expected: "a\nb\nb\nc\n"},
+		{name: "it merges", mode: ConflictMerge, expected: "a\nb\nc\n"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			gn := newTestGN(t)
+			writeTestNote(t, gn, "billing", "main", "a\nb\n")
+			writeTestNote(t, gn, "billing", "spinoff", "b\nc\n")
+
+			err := gn.Copy(NoteRef{"billing", "spinoff"}, NoteRef{"billing", "main"}, tc.mode)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, readTestNote(t, gn, "billing", "main"))
+			assert.Equal(t, "b\nc\n", readTestNote(t, gn, "billing", "spinoff"))
+		})
+	}
+}
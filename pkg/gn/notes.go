@@ -0,0 +1,397 @@
+package gn
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// NoteRef identifies a note by its project and branch
+type NoteRef struct {
+	Project string
+	Branch  string
+}
+
+// ParseNoteRef parses a reference in the form project/branch.
+// The project is everything before the first slash, so branch names
+// containing slashes (e.g. feat/login) are kept intact.
+// Empty, . and .. segments are rejected, so references stay inside the notes path
+func ParseNoteRef(s string) (NoteRef, error) {
+	project, branch, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok || project == "" || branch == "" {
+		return NoteRef{}, errflags.New("note reference must be in the form project/branch", errflags.BadParameter)
+	}
+	for _, segment := range strings.Split(project+"/"+branch, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return NoteRef{}, errflags.New(fmt.Sprintf("invalid note reference %s: empty, . and .. segments are not allowed", s), errflags.BadParameter)
+		}
+	}
+
+	return NoteRef{Project: project, Branch: branch}, nil
+}
+
+// String returns the reference in the form project/branch,
+// or only the project if there is no branch
+func (n NoteRef) String() string {
+	if n.Branch == "" {
+		return n.Project
+	}
+	return n.Project + "/" + n.Branch
+}
+
+// path returns the path of the note inside notesPath
+func (n NoteRef) path(notesPath string) string {
+	return getNotePath(notesPath, n.Project, n.Branch)
+}
+
+// CurrentNote returns the reference of the note of the selected
+// project and branch, or of the working directory ones
+func (gn *GN) CurrentNote() (NoteRef, error) {
+	project, err := gn.findProject()
+	if err != nil {
+		return NoteRef{}, err
+	}
+
+	branch, err := gn.findBranch()
+	if err != nil {
+		return NoteRef{}, err
+	}
+
+	return NoteRef{Project: project, Branch: branch}, nil
+}
+
+// Note is a note loaded from the notes path
+type Note struct {
+	NoteRef
+	Content string
+	// Tags are the #tags and frontmatter tags of the note
+	Tags []string
+	// Archived is true for notes read from the archive
+	Archived bool
+	// Modified is the last time the note changed. With NoteFilter.Modified,
+	// it is the time of the last commit changing it or, if it has uncommited
+	// changes, the time of its file. Otherwise it is the time of its file
+	Modified time.Time
+}
+
+// NoteFilter selects the notes returned by loadNotes
+type NoteFilter struct {
+	// Project, if not empty, selects only notes of this project
+	Project string
+	// Tag, if not empty, selects only notes with this tag
+	Tag string
+	// Archived includes archived notes
+	Archived bool
+	// Since and Until, if not zero, select only notes
+	// modified in this time range
+	Since time.Time
+	Until time.Time
+	// Modified takes Note.Modified from the notes history, which is slower
+	// than the file times used otherwise. It is implied by Since and Until
+	Modified bool
+}
+
+// loadNotes reads the notes selected by filter and extracts their tags.
+// Every command that reads notes across the notes path should use it,
+// so that filters work the same for all of them
+func (gn *GN) loadNotes(filter NoteFilter) ([]Note, error) {
+	refs, err := gn.listNotes(filter.Project)
+	if err != nil {
+		return nil, err
+	}
+
+	type notePath struct {
+		ref      NoteRef
+		path     string
+		archived bool
+	}
+	paths := []notePath{}
+	for _, ref := range refs {
+		paths = append(paths, notePath{ref, ref.path(gn.NotesPath), false})
+	}
+	if filter.Archived {
+		archived, err := gn.ListArchive(filter.Project)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range archived {
+			paths = append(paths, notePath{a.Ref(), a.path(gn.NotesPath), true})
+		}
+	}
+
+	rels := []string{}
+	for _, p := range paths {
+		rel, err := gn.relPath(p.path)
+		if err != nil {
+			return nil, err
+		}
+		rels = append(rels, rel)
+	}
+	times := map[string]time.Time{}
+	if filter.Modified || !filter.Since.IsZero() || !filter.Until.IsZero() {
+		times, err = gn.lastCommitTimes(rels)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	notes := []Note{}
+	for i, p := range paths {
+		modified, ok := times[rels[i]]
+		if !ok {
+			fi, err := os.Stat(p.path)
+			if err != nil {
+				return nil, err
+			}
+			modified = fi.ModTime()
+		}
+		if (!filter.Since.IsZero() && modified.Before(filter.Since)) ||
+			(!filter.Until.IsZero() && modified.After(filter.Until)) {
+			continue
+		}
+
+		content, err := os.ReadFile(p.path)
+		if err != nil {
+			return nil, err
+		}
+		n := Note{
+			NoteRef:  p.ref,
+			Content:  string(content),
+			Tags:     extractTags(string(content)),
+			Archived: p.archived,
+			Modified: modified,
+		}
+		if filter.Tag != "" && !hasTag(n.Tags, filter.Tag) {
+			continue
+		}
+		notes = append(notes, n)
+	}
+
+	return notes, nil
+}
+
+// lastCommitTimes returns, for the files at paths relative to the notes path,
+// the time of the last commit of the notes repository that changed them.
+// Files with uncommited changes or never commited are left out.
+// File times can not be used instead, as clone, pull and checkout reset them
+func (gn *GN) lastCommitTimes(paths []string) (map[string]time.Time, error) {
+	times := map[string]time.Time{}
+	r, err := git.PlainOpen(gn.NotesPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			return times, nil
+		}
+		return nil, err
+	}
+	head, err := r.Head()
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return times, nil
+		}
+		return nil, err
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		return nil, err
+	}
+	status, err := w.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := map[string]bool{}
+	for _, p := range paths {
+		if f, ok := status[p]; ok && (f.Worktree != git.Unmodified || f.Staging != git.Unmodified) {
+			continue
+		}
+		pending[p] = true
+	}
+	if len(pending) == 0 {
+		return times, nil
+	}
+
+	iter, err := r.Log(&git.LogOptions{From: head.Hash(), Order: git.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+	err = iter.ForEach(func(c *object.Commit) error {
+		tree, err := c.Tree()
+		if err != nil {
+			return err
+		}
+		parentTree := &object.Tree{}
+		if c.NumParents() > 0 {
+			parent, err := c.Parent(0)
+			if err != nil {
+				return err
+			}
+			if parentTree, err = parent.Tree(); err != nil {
+				return err
+			}
+		}
+		changes, err := object.DiffTree(parentTree, tree)
+		if err != nil {
+			return err
+		}
+		for _, ch := range changes {
+			name := ch.To.Name
+			if name == "" {
+				name = ch.From.Name
+			}
+			if pending[name] {
+				times[name] = c.Committer.When
+				delete(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	return times, err
+}
+
+// readNote returns the content of the note of ref
+func (gn *GN) readNote(ref NoteRef) (string, error) {
+	content, err := os.ReadFile(ref.path(gn.NotesPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", errflags.Flag(fmt.Errorf("note %s not found", ref), errflags.NotFound)
+		}
+		return "", err
+	}
+	return string(content), nil
+}
+
+// listNotes returns the notes stored in the notes path.
+// If project is not empty, only the notes of that project are returned.
+// Files and directories starting with a dot (.git, .trash, ...) are not notes and are skipped
+func (gn *GN) listNotes(project string) ([]NoteRef, error) {
+	root := gn.NotesPath
+	if project != "" {
+		root = filepath.Join(gn.NotesPath, project)
+	}
+
+	notes := []NoteRef{}
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == root {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") && path != root {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := gn.relPath(path)
+		if err != nil {
+			return err
+		}
+		ref, err := ParseNoteRef(rel)
+		if err != nil {
+			// files directly inside the notes path are not notes
+			return nil
+		}
+		notes = append(notes, ref)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// commitPaths stages the given paths and commits them with msg.
+// Paths are relative to the notes path and may be files or directories,
+// including ones that were removed from disk. Nothing is commited
+// if none of the paths changed
+func (gn *GN) commitPaths(msg string, paths ...string) error {
+	// run `git init` into notes path
+	// we can still procceed if it errors
+	if err := gn.init(); err != nil {
+		gn.log.Debug("failed to init: %s", err.Error())
+	}
+
+	r, err := git.PlainOpen(gn.NotesPath)
+	if err != nil {
+		return err
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return err
+	}
+
+	status, err := w.Status()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for name := range status {
+		if !isInPaths(name, paths) {
+			continue
+		}
+		if _, err := w.Add(name); err != nil {
+			return err
+		}
+		changed = true
+	}
+
+	if !changed {
+		gn.log.Debug("nothing to commit for %v", paths)
+		return nil
+	}
+
+	return gn.commit(msg, w)
+}
+
+// isInPaths reports whether name is one of paths or is inside one of them
+func isInPaths(name string, paths []string) bool {
+	for _, p := range paths {
+		p = filepath.ToSlash(filepath.Clean(p))
+		if p == "." || name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// relPath returns path relative to the notes path, using forward slashes
+// as git does
+func (gn *GN) relPath(path string) (string, error) {
+	rel, err := filepath.Rel(gn.NotesPath, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// removeEmptyParents removes the empty directories between path and stop.
+// It is used after removing a note whose branch contains slashes, so that
+// no empty directories are left behind
+func removeEmptyParents(path string, stop string) {
+	stop = filepath.Clean(stop)
+	for dir := filepath.Dir(path); dir != stop && strings.HasPrefix(dir, stop); dir = filepath.Dir(dir) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package gn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestGN returns a GN whose notes path is a temp dir
+func newTestGN(t *testing.T) *GN {
+	gn := New(false)
+	gn.NotesPath = filepath.Join(t.TempDir(), "gitnotes")
+	gn.author = Author{Name: "test", Email: "test@example.com"}
+	return gn
+}
+
+// writeTestNote writes content into the note of project/branch
+func writeTestNote(t *testing.T, gn *GN, project string, branch string, content string) {
+	notePath := getNotePath(gn.NotesPath, project, branch)
+	err := os.MkdirAll(filepath.Dir(notePath), os.ModeDir|0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(notePath, []byte(content), 0644)
+	assert.NoError(t, err)
+}
+
+// readTestNote returns the content of the note of project/branch
+func readTestNote(t *testing.T, gn *GN, project string, branch string) string {
+	content, err := os.ReadFile(getNotePath(gn.NotesPath, project, branch))
+	assert.NoError(t, err)
+	return string(content)
+}
+
+func TestParseNoteRef(t *testing.T) {
+	tt := []struct {
+		name      string
+		input     string
+		expected  NoteRef
+		expectErr bool
+	}{
+		{
+			name:     "project and branch",
+			input:    "billing/main",
+			expected: NoteRef{Project: "billing", Branch: "main"},
+		},
+		{
+			name:     "branch with slashes",
+			input:    "billing/feat/refunds",
+			expected: NoteRef{Project: "billing", Branch: "feat/refunds"},
+		},
+		{
+			name:      "missing branch",
+			input:     "billing",
+			expectErr: true,
+		},
+		{
+			name:      "empty project",
+			input:     "/main",
+			expectErr: true,
+		},
+		{
+			name:      "parent project",
+			input:     "../main",
+			expectErr: true,
+		},
+		{
+			name:      "parent in branch",
+			input:     "billing/feat/../../../etc",
+			expectErr: true,
+		},
+		{
+			name:      "current dir",
+			input:     "billing/./main",
+			expectErr: true,
+		},
+		{
+			name:      "empty segment",
+			input:     "billing//main",
+			expectErr: true,
+		},
+		{
+			name:      "trailing slash",
+			input:     "billing/main/",
+			expectErr: true,
+		},
+		{
+			name:      "absolute",
+			input:     "//etc/passwd",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ref, err := ParseNoteRef(tc.input)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestLoadNotesModified(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "# Billing\n")
+	writeTestNote(t, gn, "billing", "feat/old", "# Old\n")
+	writeTestNote(t, gn, "shop", "main", "# Shop\n")
+
+	r, err := git.PlainInit(gn.NotesPath, false)
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	commitAt := func(path string, when time.Time) {
+		_, err := w.Add(path)
+		assert.NoError(t, err)
+		sig := &object.Signature{Name: "test", Email: "test@example.com", When: when}
+		_, err = w.Commit("Update "+path, &git.CommitOptions{Author: sig, Committer: sig})
+		assert.NoError(t, err)
+	}
+	now := time.Now()
+	old := now.AddDate(0, 0, -30)
+	commitAt("billing/feat/old", old.AddDate(0, 0, -1))
+	commitAt("billing/main", old.AddDate(0, 0, -1))
+	writeTestNote(t, gn, "billing", "feat/old", "# Old\nmore\n")
+	commitAt("billing/feat/old", old)
+	commitAt("shop/main", old)
+
+	// a fresh clone or pull sets every file time to now
+	for _, ref := range []NoteRef{{"billing", "main"}, {"billing", "feat/old"}, {"shop", "main"}} {
+		assert.NoError(t, os.Chtimes(ref.path(gn.NotesPath), now, now))
+	}
+	// uncommited changes use the file time
+	writeTestNote(t, gn, "shop", "main", "# Shop\nedited\n")
+
+	// without Modified, the history is not read
+	notes, err := gn.loadNotes(NoteFilter{})
+	assert.NoError(t, err)
+	for _, n := range notes {
+		assert.False(t, n.Modified.Before(now.Add(-time.Minute)), n.String())
+	}
+
+	notes, err = gn.loadNotes(NoteFilter{Modified: true})
+	assert.NoError(t, err)
+	modified := map[string]time.Time{}
+	for _, n := range notes {
+		modified[n.String()] = n.Modified
+	}
+	assert.True(t, modified["billing/feat/old"].Equal(old.Truncate(time.Second)), modified["billing/feat/old"])
+	assert.True(t, modified["billing/main"].Equal(old.AddDate(0, 0, -1).Truncate(time.Second)))
+	assert.False(t, modified["shop/main"].Before(now.Add(-time.Minute)))
+
+	notes, err = gn.loadNotes(NoteFilter{Since: now.AddDate(0, 0, -7)})
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+	assert.Equal(t, "shop/main", notes[0].String())
+}
@@ -0,0 +1,85 @@
+package gn
+
+import (
+	"sort"
+	"strings"
+)
+
+// Heading is a Markdown heading of a note, with the headings nested under it
+type Heading struct {
+	Level int    `json:"level"`
+	Text  string `json:"text"`
+	// Line is the line of the heading in the note
+	Line     int       `json:"line"`
+	Children []Heading `json:"children,omitempty"`
+}
+
+// NoteOutline is the outline of the headings of a note
+type NoteOutline struct {
+	Project  string    `json:"project"`
+	Branch   string    `json:"branch"`
+	Archived bool      `json:"archived,omitempty"`
+	Headings []Heading `json:"headings"`
+}
+
+// Outline returns the headings of the notes selected by filter, nested by level.
+// If depth is greater than zero, headings deeper than depth are left out
+func (gn *GN) Outline(filter NoteFilter, depth int) ([]NoteOutline, error) {
+	notes, err := gn.loadNotes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	outlines := []NoteOutline{}
+	for _, n := range notes {
+		outlines = append(outlines, NoteOutline{
+			Project:  n.Project,
+			Branch:   n.Branch,
+			Archived: n.Archived,
+			Headings: nestHeadings(parseHeadings(n.Content, depth)),
+		})
+	}
+
+	// archived notes come last from loadNotes, keep them with their project
+	sort.SliceStable(outlines, func(i, j int) bool {
+		return outlines[i].Project < outlines[j].Project
+	})
+	return outlines, nil
+}
+
+// parseHeadings returns the headings of content up to depth, in order.
+// Headings inside code blocks and the frontmatter are skipped
+func parseHeadings(content string, depth int) []Heading {
+	_, body, _ := ParseFrontmatter(content)
+	offset := strings.Count(content[:len(content)-len(body)], "\n")
+
+	headings := []Heading{}
+	for _, h := range scanHeadings(strings.Split(body, "\n")) {
+		if depth > 0 && h.Level > depth {
+			continue
+		}
+		h.Line += offset
+		headings = append(headings, h)
+	}
+	return headings
+}
+
+// nestHeadings nests each heading under the closest previous heading
+// of a lower level
+func nestHeadings(flat []Heading) []Heading {
+	nested := []Heading{}
+	for i := 0; i < len(flat); {
+		h := flat[i]
+		// the children of h are the headings until the next one of the same or lower level
+		j := i + 1
+		for j < len(flat) && flat[j].Level > h.Level {
+			j++
+		}
+		if j > i+1 {
+			h.Children = nestHeadings(flat[i+1 : j])
+		}
+		nested = append(nested, h)
+		i = j
+	}
+	return nested
+}
@@ -0,0 +1,58 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeadings(t *testing.T) {
+	content := "---\n# not a heading\n---\n# Login\n## Decisions\n```sh\n# comment\n```\n### Tokens\n## Testing\n#tag\n"
+
+	headings := parseHeadings(content, 0)
+	assert.Equal(t, []Heading{
+		{Level: 1, Text: "Login", Line: 4},
+		{Level: 2, Text: "Decisions", Line: 5},
+		{Level: 3, Text: "Tokens", Line: 9},
+		{Level: 2, Text: "Testing", Line: 10},
+	}, headings)
+
+	assert.Len(t, parseHeadings(content, 2), 3)
+}
+
+func TestNestHeadings(t *testing.T) {
+	flat := []Heading{
+		{Level: 2, Text: "Intro"},
+		{Level: 1, Text: "Login"},
+		{Level: 3, Text: "Tokens"},
+		{Level: 2, Text: "Testing"},
+		{Level: 1, Text: "Follow-ups"},
+	}
+
+	assert.Equal(t, []Heading{
+		{Level: 2, Text: "Intro"},
+		{Level: 1, Text: "Login", Children: []Heading{
+			{Level: 3, Text: "Tokens"},
+			{Level: 2, Text: "Testing"},
+		}},
+		{Level: 1, Text: "Follow-ups"},
+	}, nestHeadings(flat))
+}
+
+func TestOutline(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "# Billing\n## Decisions\n")
+	writeTestNote(t, gn, "shop", "feat/cart", "# Cart\n")
+
+	outlines, err := gn.Outline(NoteFilter{Project: "billing"}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []NoteOutline{{
+		Project:  "billing",
+		Branch:   "main",
+		Headings: []Heading{{Level: 1, Text: "Billing", Line: 1}},
+	}}, outlines)
+
+	outlines, err = gn.Outline(NoteFilter{}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, outlines, 2)
+}
@@ -0,0 +1,108 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// pinsFile is the file, inside metaDir, listing the pinned notes
+const pinsFile = "pins"
+
+// pinsPath returns the path of the pins file
+func (gn *GN) pinsPath() string {
+	return filepath.Join(gn.NotesPath, metaDir, pinsFile)
+}
+
+// Pins returns the pinned notes, sorted
+func (gn *GN) Pins() ([]NoteRef, error) {
+	content, err := os.ReadFile(gn.pinsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []NoteRef{}, nil
+		}
+		return nil, err
+	}
+
+	pins := []NoteRef{}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		ref, err := ParseNoteRef(line)
+		if err != nil {
+			gn.log.Debug("skipping pin %s: %s", line, err.Error())
+			continue
+		}
+		pins = append(pins, ref)
+	}
+	return pins, nil
+}
+
+// Pin pins the note of ref. The note must exist
+func (gn *GN) Pin(ref NoteRef) error {
+	if _, err := gn.readNote(ref); err != nil {
+		return err
+	}
+
+	pins, err := gn.Pins()
+	if err != nil {
+		return err
+	}
+	for _, p := range pins {
+		if p == ref {
+			return nil
+		}
+	}
+
+	return gn.writePins(append(pins, ref), fmt.Sprintf("Pin %s", ref))
+}
+
+// Unpin unpins the note of ref
+func (gn *GN) Unpin(ref NoteRef) error {
+	pins, err := gn.Pins()
+	if err != nil {
+		return err
+	}
+
+	kept := []NoteRef{}
+	for _, p := range pins {
+		if p != ref {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == len(pins) {
+		return errflags.New(fmt.Sprintf("note %s is not pinned", ref), errflags.NotFound)
+	}
+
+	return gn.writePins(kept, fmt.Sprintf("Unpin %s", ref))
+}
+
+// writePins writes the pins file. It is commited with msg if AlwaysCommit is set
+func (gn *GN) writePins(pins []NoteRef, msg string) error {
+	sort.Slice(pins, func(i, j int) bool {
+		return pins[i].String() < pins[j].String()
+	})
+
+	var sb strings.Builder
+	for _, p := range pins {
+		sb.WriteString(p.String() + "\n")
+	}
+
+	path := gn.pinsPath()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModeDir|0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return err
+	}
+
+	if !gn.AlwaysCommit {
+		return nil
+	}
+	return gn.commitPaths(msg, filepath.Join(metaDir, pinsFile))
+}
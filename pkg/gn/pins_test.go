@@ -0,0 +1,31 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPinAndUnpin(t *testing.T) {
+	gn := newTestGN(t)
+	main := NoteRef{Project: "billing", Branch: "main"}
+	feat := NoteRef{Project: "billing", Branch: "feat/refunds"}
+	writeTestNote(t, gn, "billing", "main", "")
+	writeTestNote(t, gn, "billing", "feat/refunds", "")
+
+	assert.NoError(t, gn.Pin(main))
+	assert.NoError(t, gn.Pin(feat))
+	// pinning twice is a no-op
+	assert.NoError(t, gn.Pin(main))
+	assert.Error(t, gn.Pin(NoteRef{Project: "billing", Branch: "missing"}))
+
+	pins, err := gn.Pins()
+	assert.NoError(t, err)
+	assert.Equal(t, []NoteRef{feat, main}, pins)
+
+	assert.NoError(t, gn.Unpin(feat))
+	assert.Error(t, gn.Unpin(feat))
+	pins, err = gn.Pins()
+	assert.NoError(t, err)
+	assert.Equal(t, []NoteRef{main}, pins)
+}
@@ -0,0 +1,118 @@
+package gn
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// defaultPRTemplate is the template of `gn pr-body` if the config does not set one
+const defaultPRTemplate = `{{with .Ticket}}Ticket: {{.}}
+
+{{end}}{{with section "Summary"}}## Summary
+
+{{.}}
+
+{{end}}{{with section "Testing"}}## Testing
+
+{{.}}
+
+{{end}}{{with section "Risks"}}## Risks
+
+{{.}}
+
+{{end}}{{if .Commits}}## Commits
+
+{{range .Commits}}- {{.Subject}} ({{.Hash}})
+{{end}}{{end}}`
+
+// PRCommit is a commit of the branch, as listed in a pull request description
+type PRCommit struct {
+	// Hash is the short hash of the commit
+	Hash    string
+	Subject string
+}
+
+// PRData holds the variables of pull request templates. Note sections are
+// read with the section function, e.g. {{section "Summary"}}
+type PRData struct {
+	Project string
+	Branch  string
+	// Base is the default branch the pull request is merged into
+	Base string
+	// Ticket is the ticket key found in the branch name, e.g. BILL-42
+	Ticket string
+	// Title is the text of the first level 1 heading of the note
+	Title string
+	// Commits are the commits of the branch since its merge base with Base, oldest first
+	Commits []PRCommit
+}
+
+// PRBody renders the note of the current branch into a pull request description,
+// using the template at PRTemplate or the default one. It only reads the
+// local repositories, so it can be piped to `gh pr create --body-file -`
+func (gn *GN) PRBody() (string, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return "", err
+	}
+	content, err := gn.readNote(ref)
+	if err != nil {
+		return "", err
+	}
+	_, body, _ := ParseFrontmatter(content)
+
+	data := PRData{Project: ref.Project, Branch: ref.Branch, Ticket: ticketFromBranch(ref.Branch), Commits: []PRCommit{}}
+	for _, h := range parseHeadings(body, 1) {
+		data.Title = h.Text
+		break
+	}
+
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return "", err
+	}
+	head, defaultBranch, mergeBase, err := gn.branchBase(r, ref.Branch)
+	if err != nil {
+		return "", err
+	}
+	data.Base = defaultBranch
+	if mergeBase != nil {
+		commits, err := revList(r, head.Hash, mergeBase.Hash)
+		if err != nil {
+			return "", err
+		}
+		for i := len(commits) - 1; i >= 0; i-- {
+			subject, _, _ := strings.Cut(commits[i].Message, "\n")
+			data.Commits = append(data.Commits, PRCommit{Hash: commits[i].Hash.String()[:7], Subject: subject})
+		}
+	}
+
+	text := defaultPRTemplate
+	name := "pr-body"
+	if gn.PRTemplate != "" {
+		t, err := os.ReadFile(gn.PRTemplate)
+		if err != nil {
+			return "", err
+		}
+		text, name = string(t), filepath.Base(gn.PRTemplate)
+	}
+
+	tmpl, err := template.New(name).Funcs(template.FuncMap{
+		"section": func(name string) string {
+			s, _ := extractSection(body, name)
+			return s
+		},
+	}).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()) + "\n", nil
+}
@@ -0,0 +1,38 @@
+package gn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPRBody(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/BILL-7-login", true)
+	first := tr.commitFile("login.go", "package login\n", "Add login")
+	second := tr.commitFile("login.go", "package login\n\nfunc Login() {}\n", "Add Login func\n\nWith a body.")
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/BILL-7-login"
+	writeTestNote(t, gn, "billing", "feat/BILL-7-login", "---\nstatus: draft\n---\n# Login\n## Summary\nAdds login.\n## Notes\nprivate\n## Testing\nUnit tests.\n")
+
+	body, err := gn.PRBody()
+	assert.NoError(t, err)
+	expected := "Ticket: BILL-7\n\n" +
+		"## Summary\n\nAdds login.\n\n" +
+		"## Testing\n\nUnit tests.\n\n" +
+		"## Commits\n\n" +
+		"- Add login (" + first.String()[:7] + ")\n" +
+		"- Add Login func (" + second.String()[:7] + ")\n"
+	assert.Equal(t, expected, body)
+
+	gn.PRTemplate = filepath.Join(t.TempDir(), "pr.tmpl")
+	err = os.WriteFile(gn.PRTemplate, []byte("{{.Title}} into {{.Base}}: {{section \"notes\"}}\n"), 0644)
+	assert.NoError(t, err)
+	body, err = gn.PRBody()
+	assert.NoError(t, err)
+	assert.Equal(t, "Login into main: private\n", body)
+}
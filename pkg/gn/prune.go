@@ -0,0 +1,79 @@
+package gn
+
+import (
+	"path"
+)
+
+// PruneAction defines what Prune does with orphan notes
+type PruneAction int
+
+const (
+	// PruneDryRun only reports the orphan notes
+	PruneDryRun PruneAction = iota
+	// PruneArchive moves orphan notes into the archive
+	PruneArchive
+	// PruneDelete moves orphan notes into the trash
+	PruneDelete
+)
+
+// Prune finds the notes of the current project whose branch no longer
+// exists in the working repository, neither locally nor as a remote-tracking
+// branch, and archives or deletes them according to action.
+// Branches matching PruneKeep are never pruned.
+// It returns the orphan notes
+func (gn *GN) Prune(action PruneAction) ([]NoteRef, error) {
+	project, err := gn.findProject()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return nil, err
+	}
+
+	branches, err := listBranchNames(r)
+	if err != nil {
+		return nil, err
+	}
+	gn.log.Debug("found %d branches in working repository", len(branches))
+
+	notes, err := gn.listNotes(project)
+	if err != nil {
+		return nil, err
+	}
+
+	orphans := []NoteRef{}
+	for _, n := range notes {
+		if branches[n.Branch] || gn.keepBranch(project, n.Branch) {
+			continue
+		}
+		orphans = append(orphans, n)
+	}
+
+	for i, n := range orphans {
+		switch action {
+		case PruneArchive:
+			_, err = gn.archive(ArchivedNote{Project: n.Project, Branch: n.Branch})
+		case PruneDelete:
+			_, err = gn.trash(n)
+		}
+		if err != nil {
+			return orphans[:i], err
+		}
+	}
+
+	return orphans, nil
+}
+
+// keepBranch reports if the branch notes of project must never be pruned.
+// Keep patterns are matched with path.Match, so "release/*" keeps all release branches
+func (gn *GN) keepBranch(project string, branch string) bool {
+	patterns := append(append([]string{}, gn.PruneKeep[""]...), gn.PruneKeep[project]...)
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, branch); ok {
+			return true
+		}
+	}
+	return false
+}
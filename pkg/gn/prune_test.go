@@ -0,0 +1,42 @@
+package gn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrune(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/alive", true)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.PruneKeep = map[string][]string{"billing": {"release/*"}}
+	writeTestNote(t, gn, "billing", "main", "main")
+	writeTestNote(t, gn, "billing", "feat/alive", "alive")
+	writeTestNote(t, gn, "billing", "feat/gone", "gone")
+	writeTestNote(t, gn, "billing", "release/1.0", "kept")
+	writeTestNote(t, gn, "other", "feat/gone", "other project")
+
+	// dry run does not change anything
+	orphans, err := gn.Prune(PruneDryRun)
+	assert.NoError(t, err)
+	assert.Equal(t, []NoteRef{{Project: "billing", Branch: "feat/gone"}}, orphans)
+	assert.Equal(t, "gone", readTestNote(t, gn, "billing", "feat/gone"))
+
+	orphans, err = gn.Prune(PruneArchive)
+	assert.NoError(t, err)
+	assert.Len(t, orphans, 1)
+
+	_, err = os.Stat(getNotePath(gn.NotesPath, "billing", "feat/gone"))
+	assert.True(t, os.IsNotExist(err))
+	archived, err := os.ReadFile(filepath.Join(gn.NotesPath, archiveDir, "billing", "feat/gone", archiveNoteFile))
+	assert.NoError(t, err)
+	assert.Equal(t, "gone", string(archived))
+
+	// other projects are not touched
+	assert.Equal(t, "other project", readTestNote(t, gn, "other", "feat/gone"))
+}
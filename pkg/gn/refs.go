@@ -0,0 +1,315 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// codeRefRegex matches references to code like pkg/gn/gn.go:142 or,
+// pinned to a commit, pkg/gn/gn.go@1a2b3c4d5e6f:142. The path must have an
+// extension starting with a letter, so times like 10.30:45 are not references
+var codeRefRegex = regexp.MustCompile("(?:^|[\\s(\\[`'\"])((?:[\\w.-]+/)*[\\w-][\\w.-]*\\.[A-Za-z]\\w*)(?:@([0-9a-f]{7,40}))?:([0-9]+)\\b")
+
+// pinnedHashLen is the length of the commit hashes written by PinRefs
+const pinnedHashLen = 12
+
+// RefStatus is the result of checking a code reference
+type RefStatus int
+
+const (
+	// RefOK means the referenced line exists
+	RefOK RefStatus = iota
+	// RefMissingFile means the file does not exist
+	RefMissingFile
+	// RefMissingLine means the file is shorter than the referenced line
+	RefMissingLine
+	// RefMissingCommit means the commit of a pinned reference does not exist
+	RefMissingCommit
+)
+
+func (s RefStatus) String() string {
+	switch s {
+	case RefOK:
+		return "ok"
+	case RefMissingFile:
+		return "missing file"
+	case RefMissingLine:
+		return "missing line"
+	case RefMissingCommit:
+		return "missing commit"
+	}
+	return "unknown"
+}
+
+// CodeRef is a reference to a line of code of the working repository
+// found in a note
+type CodeRef struct {
+	// Note and NoteLine locate the reference in the notes
+	Note     NoteRef
+	NoteLine int
+	Path     string
+	// Commit is the commit the reference is pinned to, empty if it
+	// refers to the current HEAD
+	Commit string
+	Line   int
+	Status RefStatus
+}
+
+// String returns the reference as written in notes: path:line or path@commit:line
+func (c CodeRef) String() string {
+	if c.Commit != "" {
+		return fmt.Sprintf("%s@%s:%d", c.Path, c.Commit, c.Line)
+	}
+	return fmt.Sprintf("%s:%d", c.Path, c.Line)
+}
+
+// ParseCodeRef parses a reference in the form path:line or path@commit:line
+func ParseCodeRef(s string) (CodeRef, error) {
+	m := codeRefRegex.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil || m[0] != strings.TrimSpace(s) {
+		return CodeRef{}, errflags.New("code reference must be in the form path:line or path@commit:line", errflags.BadParameter)
+	}
+	line, _ := strconv.Atoi(m[3])
+	return CodeRef{Path: m[1], Commit: m[2], Line: line}, nil
+}
+
+// parseCodeRefs returns the code references of the note content
+func parseCodeRefs(ref NoteRef, content string) []CodeRef {
+	refs := []CodeRef{}
+	for i, line := range strings.Split(content, "\n") {
+		for _, m := range codeRefRegex.FindAllStringSubmatch(line, -1) {
+			n, err := strconv.Atoi(m[3])
+			if err != nil {
+				continue
+			}
+			refs = append(refs, CodeRef{Note: ref, NoteLine: i + 1, Path: m[1], Commit: m[2], Line: n})
+		}
+	}
+	return refs
+}
+
+// refResolver checks code references against the working repository,
+// caching the line count of the files it reads
+type refResolver struct {
+	r     *git.Repository
+	head  *object.Commit
+	lines map[string]int
+}
+
+// newRefResolver returns a resolver for the working repository of gn
+func (gn *GN) newRefResolver() (*refResolver, error) {
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return nil, err
+	}
+	h, err := r.Head()
+	if err != nil {
+		return nil, err
+	}
+	head, err := r.CommitObject(h.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return &refResolver{r: r, head: head, lines: map[string]int{}}, nil
+}
+
+// commit returns the commit of c, HEAD if c is not pinned
+func (rr *refResolver) commit(c CodeRef) (*object.Commit, error) {
+	if c.Commit == "" {
+		return rr.head, nil
+	}
+	h, err := rr.r.ResolveRevision(plumbing.Revision(c.Commit))
+	if err != nil {
+		return nil, err
+	}
+	return rr.r.CommitObject(*h)
+}
+
+// file returns the lines of the file of c
+func (rr *refResolver) file(c CodeRef) ([]string, RefStatus, error) {
+	commit, err := rr.commit(c)
+	if err != nil {
+		return nil, RefMissingCommit, nil
+	}
+	f, err := commit.File(filepath.ToSlash(filepath.Clean(c.Path)))
+	if err != nil {
+		if err == object.ErrFileNotFound {
+			return nil, RefMissingFile, nil
+		}
+		return nil, RefOK, err
+	}
+	lines, err := f.Lines()
+	if err != nil {
+		return nil, RefOK, err
+	}
+	return lines, RefOK, nil
+}
+
+// check returns the status of c
+func (rr *refResolver) check(c CodeRef) (RefStatus, error) {
+	key := c.Commit + "@" + c.Path
+	n, ok := rr.lines[key]
+	if !ok {
+		lines, status, err := rr.file(c)
+		if err != nil || status != RefOK {
+			return status, err
+		}
+		n = len(lines)
+		rr.lines[key] = n
+	}
+
+	if c.Line < 1 || c.Line > n {
+		return RefMissingLine, nil
+	}
+	return RefOK, nil
+}
+
+// CheckRefs returns the code references of the notes of the current project,
+// with their status in the working repository. References that are not
+// pinned are checked against HEAD
+func (gn *GN) CheckRefs() ([]CodeRef, error) {
+	project, err := gn.findProject()
+	if err != nil {
+		return nil, err
+	}
+	rr, err := gn.newRefResolver()
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := gn.loadNotes(NoteFilter{Project: project})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := []CodeRef{}
+	for _, n := range notes {
+		for _, c := range parseCodeRefs(n.NoteRef, n.Content) {
+			if c.Status, err = rr.check(c); err != nil {
+				return nil, err
+			}
+			refs = append(refs, c)
+		}
+	}
+	return refs, nil
+}
+
+// PinRefs rewrites the valid references of refs that are not pinned
+// as references pinned to HEAD, and commits the changed notes.
+// It returns the pinned references
+func (gn *GN) PinRefs(refs []CodeRef) ([]CodeRef, error) {
+	rr, err := gn.newRefResolver()
+	if err != nil {
+		return nil, err
+	}
+	head := rr.head.Hash.String()[:pinnedHashLen]
+
+	// references to pin, by note and line of the note
+	toPin := map[NoteRef]map[int]bool{}
+	for _, c := range refs {
+		if c.Commit != "" || c.Status != RefOK {
+			continue
+		}
+		if toPin[c.Note] == nil {
+			toPin[c.Note] = map[int]bool{}
+		}
+		toPin[c.Note][c.NoteLine] = true
+	}
+
+	pinned := []CodeRef{}
+	paths := []string{}
+	for ref, lines := range toPin {
+		content, err := gn.readNote(ref)
+		if err != nil {
+			return nil, err
+		}
+
+		noteLines := strings.Split(content, "\n")
+		for i := range noteLines {
+			if !lines[i+1] {
+				continue
+			}
+			noteLines[i] = pinLine(noteLines[i], head, func(c CodeRef) bool {
+				// only pin what was checked, the note may have changed since
+				status, err := rr.check(c)
+				if err != nil || status != RefOK {
+					return false
+				}
+				c.Note, c.NoteLine, c.Commit = ref, i+1, head
+				pinned = append(pinned, c)
+				return true
+			})
+		}
+
+		if err := os.WriteFile(ref.path(gn.NotesPath), []byte(strings.Join(noteLines, "\n")), 0644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, filepath.Join(ref.Project, ref.Branch))
+	}
+
+	if len(pinned) == 0 {
+		return pinned, nil
+	}
+	return pinned, gn.commitPaths(fmt.Sprintf("Pin code references to %s", head), paths...)
+}
+
+// pinLine pins the references of line that are not pinned to commit,
+// for which pin returns true
+func pinLine(line string, commit string, pin func(CodeRef) bool) string {
+	var sb strings.Builder
+	last := 0
+	for _, m := range codeRefRegex.FindAllStringSubmatchIndex(line, -1) {
+		// m[2:4] is the path, m[4:6] the commit and m[6:8] the line
+		if m[4] >= 0 {
+			continue
+		}
+		n, err := strconv.Atoi(line[m[6]:m[7]])
+		if err != nil || !pin(CodeRef{Path: line[m[2]:m[3]], Line: n}) {
+			continue
+		}
+		sb.WriteString(line[last:m[3]])
+		sb.WriteString("@" + commit)
+		last = m[3]
+	}
+	sb.WriteString(line[last:])
+	return sb.String()
+}
+
+// ReadCodeRef returns the lines of code around c, with context lines
+// before and after it, and the number of the first returned line
+func (gn *GN) ReadCodeRef(c CodeRef, context int) ([]string, int, error) {
+	rr, err := gn.newRefResolver()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	lines, status, err := rr.file(c)
+	if err != nil {
+		return nil, 0, err
+	}
+	if status == RefOK && (c.Line < 1 || c.Line > len(lines)) {
+		status = RefMissingLine
+	}
+	if status != RefOK {
+		return nil, 0, errflags.New(fmt.Sprintf("%s: %s", c, status), errflags.NotFound)
+	}
+
+	start := c.Line - context
+	if start < 1 {
+		start = 1
+	}
+	end := c.Line + context
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return lines[start-1 : end], start, nil
+}
@@ -0,0 +1,70 @@
+package gn
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeRefs(t *testing.T) {
+	content := "See pkg/gn/gn.go:142 and (notes.go@1a2b3c4:7).\nMeeting at 10.30:45, https://example.com:8080\n`main.go:3`"
+
+	refs := parseCodeRefs(NoteRef{Project: "gn", Branch: "main"}, content)
+	found := []string{}
+	for _, r := range refs {
+		found = append(found, r.String())
+	}
+	assert.Equal(t, []string{"pkg/gn/gn.go:142", "notes.go@1a2b3c4:7", "main.go:3"}, found)
+	assert.Equal(t, 1, refs[0].NoteLine)
+	assert.Equal(t, "1a2b3c4", refs[1].Commit)
+	assert.Equal(t, 3, refs[2].NoteLine)
+}
+
+func TestParseCodeRef(t *testing.T) {
+	c, err := ParseCodeRef("pkg/gn/gn.go@1a2b3c4:12")
+	assert.NoError(t, err)
+	assert.Equal(t, CodeRef{Path: "pkg/gn/gn.go", Commit: "1a2b3c4", Line: 12}, c)
+
+	_, err = ParseCodeRef("pkg/gn/gn.go")
+	assert.Error(t, err)
+}
+
+func TestCheckAndPinRefs(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.commitFile("pkg/a.go", "package a\n\nfunc A() {}\n", "Add a")
+
+	gn := newTestGN(t)
+	gn.Project = "proj"
+	writeTestNote(t, gn, "proj", "main", "A is at pkg/a.go:3, not pkg/a.go:9\nmissing.go:1\n")
+
+	refs, err := gn.CheckRefs()
+	assert.NoError(t, err)
+	assert.Len(t, refs, 3)
+	assert.Equal(t, RefOK, refs[0].Status)
+	assert.Equal(t, RefMissingLine, refs[1].Status)
+	assert.Equal(t, RefMissingFile, refs[2].Status)
+
+	pinned, err := gn.PinRefs(refs)
+	assert.NoError(t, err)
+	assert.Len(t, pinned, 1)
+	head, err := tr.repo.Head()
+	assert.NoError(t, err)
+	sha := head.Hash().String()[:pinnedHashLen]
+	content := readTestNote(t, gn, "proj", "main")
+	assert.Equal(t, "A is at pkg/a.go@"+sha+":3, not pkg/a.go:9\nmissing.go:1\n", content)
+
+	// the pinned reference still resolves after the file changes
+	tr.commitFile("pkg/a.go", "package a\n", "Shrink a")
+	refs, err = gn.CheckRefs()
+	assert.NoError(t, err)
+	assert.Equal(t, RefOK, refs[0].Status)
+
+	lines, start, err := gn.ReadCodeRef(pinned[0], 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, start)
+	assert.Equal(t, "func A() {}", strings.TrimSpace(lines[1]))
+
+	_, _, err = gn.ReadCodeRef(CodeRef{Path: "pkg/a.go", Line: 3}, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,150 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// changelogFile is the project-level note, inside the project directory,
+// that merged branch notes are rolled up into
+const changelogFile = ".changelog"
+
+// defaultRollupSections are the note sections rolled up
+// when RollupSections is not set
+var defaultRollupSections = []string{"Decisions", "Follow-ups"}
+
+// Rollup appends the configured sections of the notes of the current project's
+// merged branches into the project changelog, under a heading with the merge date,
+// branch and merge commit. Both notes still in the project and archived notes are
+// rolled up. Each merged branch is added only once, so running it again is safe.
+// It returns the notes that were added to the changelog
+func (gn *GN) Rollup() ([]ArchivedNote, error) {
+	project, err := gn.findProject()
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := gn.findMergedNotes(project)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := gn.ListArchive(project)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range archived {
+		if a.MergeCommit != "" {
+			merged = append(merged, a)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Merged.Before(merged[j].Merged)
+	})
+
+	changelogPath := gn.ChangelogPath(project)
+	changelog, err := os.ReadFile(changelogPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	sections := gn.RollupSections
+	if len(sections) == 0 {
+		sections = defaultRollupSections
+	}
+
+	var sb strings.Builder
+	rolled := []ArchivedNote{}
+	for _, m := range merged {
+		marker := rollupMarker(m)
+		if strings.Contains(string(changelog), marker) || strings.Contains(sb.String(), marker) {
+			continue
+		}
+
+		notePath := m.Ref().path(gn.NotesPath)
+		if !m.Archived.IsZero() {
+			notePath = m.path(gn.NotesPath)
+		}
+		content, err := os.ReadFile(notePath)
+		if err != nil {
+			return nil, err
+		}
+
+		content = rebaseAssetLinks(content, filepath.Dir(notePath), filepath.Dir(changelogPath))
+		entry := rollupEntry(m, string(content), sections)
+		if entry == "" {
+			gn.log.Debug("no sections to roll up in %s", m.Ref())
+			continue
+		}
+		sb.WriteString(entry)
+		rolled = append(rolled, m)
+	}
+
+	if len(rolled) == 0 {
+		return rolled, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(changelogPath), os.ModeDir|0700); err != nil {
+		return nil, err
+	}
+	if len(changelog) == 0 {
+		changelog = []byte(fmt.Sprintf("# %s changelog\n", project))
+	}
+	out := appendNote(changelog, []byte(sb.String()))
+	if err := os.WriteFile(changelogPath, out, 0644); err != nil {
+		return nil, err
+	}
+
+	rel, err := gn.relPath(changelogPath)
+	if err != nil {
+		return nil, err
+	}
+	return rolled, gn.commitPaths(fmt.Sprintf("Roll up merged branches of %s", project), rel)
+}
+
+// ChangelogPath returns the path of the changelog of project
+func (gn *GN) ChangelogPath(project string) string {
+	return filepath.Join(gn.NotesPath, project, changelogFile)
+}
+
+// rollupMarker returns the comment that identifies the changelog
+// entry of a merged branch
+func rollupMarker(m ArchivedNote) string {
+	return fmt.Sprintf("<!-- gn-rollup %s %s -->", m.Branch, m.MergeCommit)
+}
+
+// rollupEntry returns the changelog entry with the sections of content.
+// It returns an empty string if content has none of the sections
+func rollupEntry(m ArchivedNote, content string, sections []string) string {
+	var body strings.Builder
+	for _, name := range sections {
+		s, ok := extractSection(content, name)
+		if !ok || s == "" {
+			continue
+		}
+		body.WriteString(fmt.Sprintf("\n### %s\n%s\n", name, s))
+	}
+	if body.Len() == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n## %s %s (merged in %.8s)\n%s\n%s",
+		m.Merged.Local().Format(time.DateOnly), m.Branch, m.MergeCommit, rollupMarker(m), body.String())
+}
+
+// ReadChangelog returns the changelog of the current project
+func (gn *GN) ReadChangelog() (string, error) {
+	project, err := gn.findProject()
+	if err != nil {
+		return "", err
+	}
+
+	content, err := os.ReadFile(gn.ChangelogPath(project))
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
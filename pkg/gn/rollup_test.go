@@ -0,0 +1,49 @@
+package gn
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRollup(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.checkout("feat/refunds", true)
+	tr.commitFile("refunds.txt", "refunds\n", "Add refunds")
+	tr.checkout("main", false)
+	mergeHash := tr.merge("feat/refunds")
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	writeTestNote(t, gn, "billing", "feat/refunds", "# Refunds\n## Decisions\n- use queues\n## Scratch\nignored\n## Follow-ups\n- docs\n")
+
+	rolled, err := gn.Rollup()
+	assert.NoError(t, err)
+	assert.Len(t, rolled, 1)
+
+	changelog, err := os.ReadFile(gn.ChangelogPath("billing"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(changelog), "feat/refunds (merged in "+mergeHash.String()[:8]+")")
+	assert.Contains(t, string(changelog), "### Decisions\n- use queues\n")
+	assert.Contains(t, string(changelog), "### Follow-ups\n- docs\n")
+	assert.NotContains(t, string(changelog), "ignored")
+
+	// the changelog is not listed as a note
+	notes, err := gn.listNotes("billing")
+	assert.NoError(t, err)
+	assert.Len(t, notes, 1)
+
+	// running again does not add the branch twice, even after archiving it
+	_, err = gn.ArchiveMerged(false)
+	assert.NoError(t, err)
+	rolled, err = gn.Rollup()
+	assert.NoError(t, err)
+	assert.Empty(t, rolled)
+
+	again, err := os.ReadFile(gn.ChangelogPath("billing"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(changelog), string(again))
+	assert.Equal(t, 1, strings.Count(string(again), "### Decisions"))
+}
@@ -0,0 +1,52 @@
+package gn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SearchResult is a note line matching a search
+type SearchResult struct {
+	Note NoteRef
+	// Line is the 1-based line number of the match
+	Line     int
+	Text     string
+	Archived bool
+}
+
+// Ref returns the reference of the result, in the form project/branch:line
+func (s SearchResult) Ref() string {
+	return fmt.Sprintf("%s:%d", s.Note, s.Line)
+}
+
+// Notes returns the notes selected by filter
+func (gn *GN) Notes(filter NoteFilter) ([]Note, error) {
+	return gn.loadNotes(filter)
+}
+
+// Search returns the lines of the notes selected by filter
+// that contain query, case insensitive
+func (gn *GN) Search(query string, filter NoteFilter) ([]SearchResult, error) {
+	notes, err := gn.loadNotes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(query)
+	results := []SearchResult{}
+	for _, n := range notes {
+		for i, line := range strings.Split(n.Content, "\n") {
+			if !strings.Contains(strings.ToLower(line), query) {
+				continue
+			}
+			results = append(results, SearchResult{
+				Note:     n.NoteRef,
+				Line:     i + 1,
+				Text:     strings.TrimSpace(line),
+				Archived: n.Archived,
+			})
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,236 @@
+package gn
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// StandupNote is what changed in a note during the standup window
+type StandupNote struct {
+	NoteRef
+	// Added are the lines added to the note, without checklist
+	// items that were only checked or unchecked
+	Added []string
+	// Checked and Unchecked are the checklist items that were ticked or unticked
+	Checked   []string
+	Unchecked []string
+}
+
+// Standup is a report of what changed in the notes between Since and Until
+type Standup struct {
+	Since time.Time
+	Until time.Time
+	Notes []StandupNote
+}
+
+// Standup compares the notes of the last commit before since with the ones of
+// the last commit up to until, and returns what changed in each note.
+// Only the project of filter is used to select notes. A zero until means now
+func (gn *GN) Standup(filter NoteFilter, since time.Time, until time.Time) (Standup, error) {
+	if until.IsZero() {
+		until = time.Now()
+	}
+	standup := Standup{Since: since, Until: until, Notes: []StandupNote{}}
+
+	before, after, err := gn.notesTrees(since, until)
+	if err != nil || after == nil {
+		return standup, err
+	}
+
+	changes, err := object.DiffTree(before, after)
+	if err != nil {
+		return Standup{}, err
+	}
+
+	for _, change := range changes {
+		ref, ok := noteRefOfPath(change.To.Name)
+		if !ok || (filter.Project != "" && ref.Project != filter.Project) {
+			continue
+		}
+
+		patch, err := change.Patch()
+		if err != nil {
+			return Standup{}, err
+		}
+		added, removed := []string{}, []string{}
+		for _, fp := range patch.FilePatches() {
+			for _, chunk := range fp.Chunks() {
+				lines := strings.Split(strings.TrimSuffix(chunk.Content(), "\n"), "\n")
+				switch chunk.Type() {
+				case diff.Add:
+					added = append(added, lines...)
+				case diff.Delete:
+					removed = append(removed, lines...)
+				}
+			}
+		}
+
+		file, err := after.File(change.To.Name)
+		if err != nil {
+			return Standup{}, err
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return Standup{}, err
+		}
+
+		n := standupNote(ref, content, added, removed)
+		if len(n.Added)+len(n.Checked)+len(n.Unchecked) > 0 {
+			standup.Notes = append(standup.Notes, n)
+		}
+	}
+
+	sort.Slice(standup.Notes, func(i, j int) bool {
+		return standup.Notes[i].String() < standup.Notes[j].String()
+	})
+	return standup, nil
+}
+
+// notesTrees returns the tree of the last commit of the notes repository
+// before since and the one of the last commit until until.
+// Either is nil if there is no such commit
+func (gn *GN) notesTrees(since time.Time, until time.Time) (*object.Tree, *object.Tree, error) {
+	r, err := git.PlainOpen(gn.NotesPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	iter, err := r.Log(&git.LogOptions{Order: git.LogOrderCommitterTime})
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	var before, after *object.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		when := c.Committer.When
+		if after == nil && !when.After(until) {
+			after = c
+		}
+		if when.Before(since) {
+			before = c
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil || after == nil {
+		return nil, nil, err
+	}
+
+	afterTree, err := after.Tree()
+	if err != nil {
+		return nil, nil, err
+	}
+	if before == nil {
+		return nil, afterTree, nil
+	}
+	beforeTree, err := before.Tree()
+	return beforeTree, afterTree, err
+}
+
+// standupNote sorts the lines added to and removed from the note of ref,
+// whose content is now content, into added lines and checked and unchecked items
+func standupNote(ref NoteRef, content string, added []string, removed []string) StandupNote {
+	n := StandupNote{NoteRef: ref, Added: []string{}, Checked: []string{}, Unchecked: []string{}}
+
+	// state of the checklist items before the change, by text
+	wasDone := map[string]bool{}
+	for _, line := range removed {
+		if m := checkboxRegex.FindStringSubmatch(line); m != nil {
+			wasDone[m[4]] = m[2] != " "
+		}
+	}
+
+	// frontmatter fields like updated change on every edit
+	fm, _, _ := ParseFrontmatter(content)
+	inFrontmatter := map[string]bool{}
+	for _, line := range fm.lines {
+		inFrontmatter[line] = true
+	}
+
+	for _, line := range added {
+		if strings.TrimSpace(line) == "" || inFrontmatter[line] {
+			continue
+		}
+		if m := checkboxRegex.FindStringSubmatch(line); m != nil {
+			done := m[2] != " "
+			if before, ok := wasDone[m[4]]; ok && before != done {
+				if done {
+					n.Checked = append(n.Checked, m[4])
+				} else {
+					n.Unchecked = append(n.Unchecked, m[4])
+				}
+				continue
+			}
+		}
+		n.Added = append(n.Added, line)
+	}
+
+	return n
+}
+
+// WriteText writes the report as plain text
+func (s Standup) WriteText(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Notes changed since %s\n", s.Since.Format("2006-01-02 15:04")))
+	for _, n := range s.Notes {
+		sb.WriteString(fmt.Sprintf("\n%s\n", n))
+		for _, line := range n.Added {
+			sb.WriteString(fmt.Sprintf("  + %s\n", strings.TrimSpace(line)))
+		}
+		for _, item := range n.Checked {
+			sb.WriteString(fmt.Sprintf("  done: %s\n", item))
+		}
+		for _, item := range n.Unchecked {
+			sb.WriteString(fmt.Sprintf("  reopened: %s\n", item))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteMarkdown writes the report as Markdown, a section per note
+func (s Standup) WriteMarkdown(w io.Writer) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("## Standup since %s\n", s.Since.Format("2006-01-02 15:04")))
+	for _, n := range s.Notes {
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n", n))
+		for _, line := range n.Added {
+			sb.WriteString(fmt.Sprintf("- %s\n", trimListMarker(line)))
+		}
+		for _, item := range n.Checked {
+			sb.WriteString(fmt.Sprintf("- [x] %s\n", item))
+		}
+		for _, item := range n.Unchecked {
+			sb.WriteString(fmt.Sprintf("- [ ] %s (reopened)\n", item))
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// trimListMarker removes the surrounding spaces and the list marker
+// (-, * or +) of line, so it can be written as a list item
+func trimListMarker(line string) string {
+	line = strings.TrimSpace(line)
+	for _, marker := range []string{"- ", "* ", "+ "} {
+		if rest, ok := strings.CutPrefix(line, marker); ok {
+			return strings.TrimSpace(rest)
+		}
+	}
+	return line
+}
@@ -0,0 +1,49 @@
+package gn
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandup(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "---\nupdated: a\n---\n# Notes\n- [ ] write tests\n- [x] deploy\n")
+	writeTestNote(t, gn, "shop", "main", "# Shop\n")
+	assert.NoError(t, gn.commitPaths("Add notes", "."))
+
+	since := time.Now()
+	time.Sleep(1100 * time.Millisecond)
+
+	writeTestNote(t, gn, "billing", "main", "---\nupdated: b\n---\n# Notes\n- [x] write tests\n- [ ] deploy\n- [ ] new item\n\nFixed rounding\n")
+	writeTestNote(t, gn, "billing", "feat/refunds", "# Refunds\n")
+	assert.NoError(t, gn.commitPaths("Change notes", "."))
+
+	standup, err := gn.Standup(NoteFilter{}, since, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, []StandupNote{
+		{
+			NoteRef:   NoteRef{Project: "billing", Branch: "feat/refunds"},
+			Added:     []string{"# Refunds"},
+			Checked:   []string{},
+			Unchecked: []string{},
+		},
+		{
+			NoteRef:   NoteRef{Project: "billing", Branch: "main"},
+			Added:     []string{"- [ ] new item", "Fixed rounding"},
+			Checked:   []string{"write tests"},
+			Unchecked: []string{"deploy"},
+		},
+	}, standup.Notes)
+
+	var buf bytes.Buffer
+	assert.NoError(t, standup.WriteMarkdown(&buf))
+	assert.Contains(t, buf.String(), "### billing/main\n\n- [ ] new item\n- Fixed rounding\n- [x] write tests\n- [ ] deploy (reopened)\n")
+
+	// everything is new without commits before since
+	standup, err = gn.Standup(NoteFilter{Project: "shop"}, time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Len(t, standup.Notes, 1)
+}
@@ -0,0 +1,235 @@
+package gn
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// heatmapLevels are the cells of the activity heatmap, from no activity to the most
+var heatmapLevels = []string{"·", "░", "▒", "▓", "█"}
+
+// ProjectStats are the counts of the notes of a project
+type ProjectStats struct {
+	Project string `json:"project"`
+	Notes   int    `json:"notes"`
+	Words   int    `json:"words"`
+}
+
+// BranchActivity is how much the note of a branch changed in the notes repository
+type BranchActivity struct {
+	Project   string `json:"project"`
+	Branch    string `json:"branch"`
+	Commits   int    `json:"commits"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// DayActivity is the number of commits of the notes repository in a day
+type DayActivity struct {
+	Date    string `json:"date"`
+	Commits int    `json:"commits"`
+}
+
+// Stats are the statistics of the notebook
+type Stats struct {
+	Since    time.Time        `json:"since"`
+	Until    time.Time        `json:"until"`
+	Projects []ProjectStats   `json:"projects"`
+	Branches []BranchActivity `json:"branches"`
+	// Days has an entry for every day between Since and Until
+	Days []DayActivity `json:"days"`
+}
+
+// Stats returns the note and word counts of the notes of project, or of all
+// projects if project is empty, and the activity of the notes repository
+// between since and until. A zero until means now
+func (gn *GN) Stats(project string, since time.Time, until time.Time) (Stats, error) {
+	if until.IsZero() {
+		until = time.Now()
+	}
+	stats := Stats{Since: since, Until: until, Projects: []ProjectStats{}, Branches: []BranchActivity{}}
+
+	notes, err := gn.loadNotes(NoteFilter{Project: project})
+	if err != nil {
+		return Stats{}, err
+	}
+	byProject := map[string]*ProjectStats{}
+	for _, n := range notes {
+		p, ok := byProject[n.Project]
+		if !ok {
+			p = &ProjectStats{Project: n.Project}
+			byProject[n.Project] = p
+		}
+		_, body, _ := ParseFrontmatter(n.Content)
+		p.Notes++
+		p.Words += len(strings.Fields(body))
+	}
+	for _, p := range byProject {
+		stats.Projects = append(stats.Projects, *p)
+	}
+	sort.Slice(stats.Projects, func(i, j int) bool {
+		return stats.Projects[i].Project < stats.Projects[j].Project
+	})
+
+	commits, err := gn.notesLog(since, until)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	days := map[string]int{}
+	branches := map[NoteRef]*BranchActivity{}
+	for _, c := range commits {
+		fileStats, err := c.Stats()
+		if err != nil {
+			return Stats{}, err
+		}
+
+		touched := false
+		for _, f := range fileStats {
+			ref, ok := noteRefOfPath(f.Name)
+			if !ok || (project != "" && ref.Project != project) {
+				continue
+			}
+			touched = true
+			b, ok := branches[ref]
+			if !ok {
+				b = &BranchActivity{Project: ref.Project, Branch: ref.Branch}
+				branches[ref] = b
+			}
+			b.Commits++
+			b.Additions += f.Addition
+			b.Deletions += f.Deletion
+		}
+		if touched || project == "" {
+			days[c.Author.When.In(until.Location()).Format(time.DateOnly)]++
+		}
+	}
+
+	for _, b := range branches {
+		stats.Branches = append(stats.Branches, *b)
+	}
+	sort.Slice(stats.Branches, func(i, j int) bool {
+		if stats.Branches[i].Commits != stats.Branches[j].Commits {
+			return stats.Branches[i].Commits > stats.Branches[j].Commits
+		}
+		return stats.Branches[i].Project+"/"+stats.Branches[i].Branch < stats.Branches[j].Project+"/"+stats.Branches[j].Branch
+	})
+
+	first := since
+	if first.IsZero() {
+		// without a start, the activity starts at the first commit
+		first = until
+		for _, c := range commits {
+			if c.Author.When.Before(first) {
+				first = c.Author.When
+			}
+		}
+	}
+	first = first.In(until.Location())
+	for d := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, until.Location()); !d.After(until); d = d.AddDate(0, 0, 1) {
+		date := d.Format(time.DateOnly)
+		stats.Days = append(stats.Days, DayActivity{Date: date, Commits: days[date]})
+	}
+
+	return stats, nil
+}
+
+// notesLog returns the commits of the notes repository between since and until.
+// It returns no commits if the notes repository has none
+func (gn *GN) notesLog(since time.Time, until time.Time) ([]*object.Commit, error) {
+	r, err := git.PlainOpen(gn.NotesPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			return []*object.Commit{}, nil
+		}
+		return nil, err
+	}
+
+	opts := &git.LogOptions{Until: &until}
+	if !since.IsZero() {
+		opts.Since = &since
+	}
+	iter, err := r.Log(opts)
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return []*object.Commit{}, nil
+		}
+		return nil, err
+	}
+
+	commits := []*object.Commit{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	})
+	return commits, err
+}
+
+// noteRefOfPath returns the note stored at the path, relative to the
+// notes path. ok is false for files that are not notes, like the ones
+// inside the trash, the archive or the assets directories
+func noteRefOfPath(path string) (NoteRef, bool) {
+	for _, part := range strings.Split(path, "/") {
+		if strings.HasPrefix(part, ".") {
+			return NoteRef{}, false
+		}
+	}
+	ref, err := ParseNoteRef(path)
+	return ref, err == nil
+}
+
+// WriteHeatmap writes the daily activity as a heatmap of weeks (columns)
+// and weekdays (rows), starting on Monday
+func (s Stats) WriteHeatmap(w io.Writer) error {
+	if len(s.Days) == 0 {
+		return nil
+	}
+
+	most := 0
+	for _, d := range s.Days {
+		if d.Commits > most {
+			most = d.Commits
+		}
+	}
+
+	first, err := time.Parse(time.DateOnly, s.Days[0].Date)
+	if err != nil {
+		return err
+	}
+	// number of days before the first one in its week
+	pad := (int(first.Weekday()) + 6) % 7
+	weeks := (pad + len(s.Days) + 6) / 7
+
+	rows := make([][]string, 7)
+	for i := range rows {
+		rows[i] = make([]string, weeks)
+		for j := range rows[i] {
+			rows[i][j] = " "
+		}
+	}
+	for i, d := range s.Days {
+		cell := pad + i
+		level := 0
+		if d.Commits > 0 {
+			// 1 to 4, by quarter of the busiest day
+			level = 1 + (d.Commits*4-1)/most
+		}
+		rows[cell%7][cell/7] = heatmapLevels[level]
+	}
+
+	weekdays := []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"}
+	for i, row := range rows {
+		if _, err := fmt.Fprintf(w, "%s %s\n", weekdays[i], strings.Join(row, " ")); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "    less %s more\n", strings.Join(heatmapLevels, " "))
+	return err
+}
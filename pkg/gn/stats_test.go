@@ -0,0 +1,64 @@
+package gn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "---\nstatus: draft\n---\none two three\n")
+	assert.NoError(t, gn.commitPaths("Add billing", "billing"))
+	writeTestNote(t, gn, "billing", "feat/refunds", "four five\n")
+	assert.NoError(t, gn.commitPaths("Add refunds", "billing"))
+	writeTestNote(t, gn, "billing", "feat/refunds", "four five six\n")
+	assert.NoError(t, gn.commitPaths("Change refunds", "billing"))
+	writeTestNote(t, gn, "shop", "main", "seven\n")
+	assert.NoError(t, gn.commitPaths("Add shop", "shop"))
+
+	now := time.Now()
+	since := now.AddDate(0, 0, -6)
+	stats, err := gn.Stats("", since, now)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []ProjectStats{
+		{Project: "billing", Notes: 2, Words: 6},
+		{Project: "shop", Notes: 1, Words: 1},
+	}, stats.Projects)
+	assert.Equal(t, BranchActivity{Project: "billing", Branch: "feat/refunds", Commits: 2, Additions: 2, Deletions: 1}, stats.Branches[0])
+	assert.Len(t, stats.Branches, 3)
+	assert.Len(t, stats.Days, 7)
+	assert.Equal(t, DayActivity{Date: now.Format(time.DateOnly), Commits: 4}, stats.Days[6])
+
+	stats, err = gn.Stats("shop", since, now)
+	assert.NoError(t, err)
+	assert.Len(t, stats.Projects, 1)
+	assert.Equal(t, 1, stats.Days[6].Commits)
+}
+
+func TestWriteHeatmap(t *testing.T) {
+	// 2024-01-03 is a Wednesday
+	stats := Stats{Days: []DayActivity{
+		{Date: "2024-01-03", Commits: 4},
+		{Date: "2024-01-04", Commits: 0},
+		{Date: "2024-01-05", Commits: 1},
+		{Date: "2024-01-06", Commits: 2},
+		{Date: "2024-01-07", Commits: 0},
+		{Date: "2024-01-08", Commits: 3},
+	}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, stats.WriteHeatmap(&buf))
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, "Mon   ▓", lines[0])
+	assert.Equal(t, "Tue    ", lines[1])
+	assert.Equal(t, "Wed █  ", lines[2])
+	assert.Equal(t, "Thu ·  ", lines[3])
+	assert.Equal(t, "Fri ░  ", lines[4])
+	assert.Equal(t, "Sat ▒  ", lines[5])
+	assert.Equal(t, "Sun ·  ", lines[6])
+}
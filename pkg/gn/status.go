@@ -0,0 +1,196 @@
+package gn
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// defaultStatuses are the statuses a note can have, in the order
+// of their lifecycle, if the config does not set others
+var defaultStatuses = []string{"draft", "in-progress", "blocked", "in-review", "done"}
+
+// statusLineRegex matches a status line in the body of a note, like
+// "Status: blocked" or "**Status**: in review"
+var statusLineRegex = regexp.MustCompile(`(?i)^\s*(?:[-*]\s+)?(?:\*\*)?status(?:\*\*)?\s*:\s*(?:\*\*)?\s*(.+?)\s*$`)
+
+// statusCommitRegex matches the message of the commits of SetStatus
+var statusCommitRegex = regexp.MustCompile(`^Set status of (\S+) to (\S+)$`)
+
+// noteStatus returns the status of a note: the status field of its
+// frontmatter or, if it has none, the first status line of its body
+func noteStatus(content string) string {
+	fm, body, _ := ParseFrontmatter(content)
+	if status, ok := fm.Get("status"); ok && status != "" {
+		return status
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		if m := statusLineRegex.FindStringSubmatch(line); m != nil {
+			return strings.TrimSuffix(m[1], "**")
+		}
+	}
+	return ""
+}
+
+// normalizeStatus returns status in lower case with dashes
+// instead of spaces, so "In review" is the same as "in-review"
+func normalizeStatus(status string) string {
+	return strings.Join(strings.Fields(strings.ToLower(status)), "-")
+}
+
+// statuses returns the statuses a note can have, in lifecycle order.
+// Configured statuses are normalized like the statuses users set
+func (gn *GN) statuses() []string {
+	if len(gn.Statuses) == 0 {
+		return defaultStatuses
+	}
+	statuses := []string{}
+	for _, s := range gn.Statuses {
+		statuses = append(statuses, normalizeStatus(s))
+	}
+	return statuses
+}
+
+// Status returns the status of the current note
+func (gn *GN) Status() (string, error) {
+	content, err := gn.ReadNote()
+	if err != nil {
+		return "", err
+	}
+	return normalizeStatus(noteStatus(content)), nil
+}
+
+// SetStatus sets the status field of the frontmatter of the current note
+// and commits it as "Set status of <project>/<branch> to <status>",
+// which StatusHistory reads back
+func (gn *GN) SetStatus(status string) error {
+	status = normalizeStatus(status)
+	valid := false
+	for _, s := range gn.statuses() {
+		if s == status {
+			valid = true
+		}
+	}
+	if !valid {
+		return errflags.New(fmt.Sprintf("unknown status %s, expected one of %v", status, gn.statuses()), errflags.BadParameter)
+	}
+
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return err
+	}
+	if _, err := gn.readNote(ref); err != nil {
+		return err
+	}
+
+	if err := gn.writeMeta(ref, map[string]string{"status": status}); err != nil {
+		return err
+	}
+	return gn.commitPaths(fmt.Sprintf("Set status of %s to %s", ref, status), filepath.Join(ref.Project, ref.Branch))
+}
+
+// StatusChange is a status change of a note, read from the notes repository history
+type StatusChange struct {
+	Note   NoteRef
+	Status string
+	When   time.Time
+	Commit plumbing.Hash
+}
+
+// StatusHistory returns the status changes of the current note, oldest first
+func (gn *GN) StatusHistory() ([]StatusChange, error) {
+	ref, err := gn.CurrentNote()
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := git.PlainOpen(gn.NotesPath)
+	if err != nil {
+		if err == git.ErrRepositoryNotExists {
+			return []StatusChange{}, nil
+		}
+		return nil, err
+	}
+	iter, err := r.Log(&git.LogOptions{})
+	if err != nil {
+		if err == plumbing.ErrReferenceNotFound {
+			return []StatusChange{}, nil
+		}
+		return nil, err
+	}
+
+	changes := []StatusChange{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		m := statusCommitRegex.FindStringSubmatch(strings.TrimSpace(c.Message))
+		if m == nil || m[1] != ref.String() {
+			return nil
+		}
+		changes = append(changes, StatusChange{Note: ref, Status: m[2], When: c.Committer.When, Commit: c.Hash})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the log is newest first
+	for i, j := 0, len(changes)-1; i < j; i, j = i+1, j-1 {
+		changes[i], changes[j] = changes[j], changes[i]
+	}
+	return changes, nil
+}
+
+// BoardColumn is a column of the board: the notes with a status
+type BoardColumn struct {
+	Status string
+	Notes  []NoteRef
+}
+
+// Board returns the notes selected by filter grouped by status. Columns follow
+// the lifecycle order, followed by unknown statuses in alphabetical order.
+// Notes without status are left out unless withoutStatus is true, in which
+// case they are in a last column with an empty status
+func (gn *GN) Board(filter NoteFilter, withoutStatus bool) ([]BoardColumn, error) {
+	notes, err := gn.loadNotes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus := map[string][]NoteRef{}
+	for _, n := range notes {
+		status := normalizeStatus(noteStatus(n.Content))
+		if status == "" && !withoutStatus {
+			continue
+		}
+		byStatus[status] = append(byStatus[status], n.NoteRef)
+	}
+
+	columns := []BoardColumn{}
+	for _, s := range gn.statuses() {
+		columns = append(columns, BoardColumn{Status: s, Notes: byStatus[s]})
+		delete(byStatus, s)
+	}
+	unknown := []string{}
+	for s := range byStatus {
+		if s != "" {
+			unknown = append(unknown, s)
+		}
+	}
+	sort.Strings(unknown)
+	for _, s := range unknown {
+		columns = append(columns, BoardColumn{Status: s, Notes: byStatus[s]})
+	}
+	if withoutStatus {
+		columns = append(columns, BoardColumn{Status: "", Notes: byStatus[""]})
+	}
+
+	return columns, nil
+}
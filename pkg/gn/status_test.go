@@ -0,0 +1,72 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoteStatus(t *testing.T) {
+	tt := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{name: "frontmatter", content: "---\nstatus: blocked\n---\nStatus: done\n", expected: "blocked"},
+		{name: "status line", content: "# Login\nStatus: in review\n", expected: "in review"},
+		{name: "bold status line", content: "- **Status:** done\n", expected: "done"},
+		{name: "no status", content: "# Login\nThe status is unclear\n", expected: ""},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, noteStatus(tc.content))
+		})
+	}
+}
+
+func TestSetStatus(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "main"
+	writeTestNote(t, gn, "billing", "main", "# Notes\n")
+
+	gn.Statuses = []string{"In progress", "In review"}
+	assert.NoError(t, gn.SetStatus("in-progress"))
+	assert.NoError(t, gn.SetStatus("In review"))
+	assert.Error(t, gn.SetStatus("shipped"))
+
+	status, err := gn.Status()
+	assert.NoError(t, err)
+	assert.Equal(t, "in-review", status)
+
+	history, err := gn.StatusHistory()
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, "in-progress", history[0].Status)
+	assert.Equal(t, "in-review", history[1].Status)
+
+	gn.Branch = "missing"
+	assert.Error(t, gn.SetStatus("in-progress"))
+}
+
+func TestBoard(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Statuses = []string{"draft", "In Review", "done"}
+	writeTestNote(t, gn, "billing", "main", "---\nstatus: done\n---\n")
+	writeTestNote(t, gn, "billing", "feat/refunds", "Status: Waiting on QA\n")
+	writeTestNote(t, gn, "billing", "feat/invoices", "Status: in review\n")
+	writeTestNote(t, gn, "shop", "main", "# Shop\n")
+
+	columns, err := gn.Board(NoteFilter{}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []BoardColumn{
+		{Status: "draft"},
+		{Status: "in-review", Notes: []NoteRef{{Project: "billing", Branch: "feat/invoices"}}},
+		{Status: "done", Notes: []NoteRef{{Project: "billing", Branch: "main"}}},
+		{Status: "waiting-on-qa", Notes: []NoteRef{{Project: "billing", Branch: "feat/refunds"}}},
+	}, columns)
+
+	columns, err = gn.Board(NoteFilter{Project: "shop"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, BoardColumn{Status: "", Notes: []NoteRef{{Project: "shop", Branch: "main"}}}, columns[3])
+}
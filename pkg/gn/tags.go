@@ -0,0 +1,92 @@
+package gn
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// tagRegex matches #tag tokens. Tags must start with a letter, so issue
+// numbers like #42 and Markdown headings are not tags
+var tagRegex = regexp.MustCompile(`(?:^|[\s(\[,])#([\p{L}][\p{L}\p{N}_\-/]*)`)
+
+// TagCount is a tag and the number of notes tagged with it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// extractTags returns the tags of the note content, lowercased and sorted.
+// Tags are #tag tokens in the note and the tags of its frontmatter.
+// Fenced code blocks are ignored
+func extractTags(content string) []string {
+	set := map[string]bool{}
+
+	frontmatter, body, ok := ParseFrontmatter(content)
+	if ok {
+		for _, t := range frontmatter.List("tags") {
+			set[strings.ToLower(strings.TrimPrefix(t, "#"))] = true
+		}
+	}
+
+	inFence := false
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		for _, m := range tagRegex.FindAllStringSubmatch(line, -1) {
+			set[strings.ToLower(strings.TrimRight(m[1], "-/"))] = true
+		}
+	}
+
+	tags := make([]string, 0, len(set))
+	for t := range set {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// hasTag reports if tags contains tag, case insensitive
+func hasTag(tags []string, tag string) bool {
+	tag = strings.ToLower(strings.TrimPrefix(tag, "#"))
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Tags returns the tags of the notes selected by filter and how many
+// notes have each of them, most used first
+func (gn *GN) Tags(filter NoteFilter) ([]TagCount, error) {
+	notes, err := gn.loadNotes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := map[string]int{}
+	for _, n := range notes {
+		for _, t := range n.Tags {
+			counts[t]++
+		}
+	}
+
+	tags := make([]TagCount, 0, len(counts))
+	for t, c := range counts {
+		tags = append(tags, TagCount{Tag: t, Count: c})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+
+	return tags, nil
+}
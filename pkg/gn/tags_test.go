@@ -0,0 +1,70 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTags(t *testing.T) {
+	tt := []struct {
+		name     string
+		content  string
+		expected []string
+	}{
+		{
+			name:     "hashtags",
+			content:  "# Title\nsome #perf work (#DB) on issue #42",
+			expected: []string{"db", "perf"},
+		},
+		{
+			name:     "inline frontmatter tags",
+			content:  "---\nstatus: draft\ntags: [perf, \"api\"]\n---\nbody #perf",
+			expected: []string{"api", "perf"},
+		},
+		{
+			name:     "block frontmatter tags",
+			content:  "---\ntags:\n  - infra\n  - ops\nowner: me\n---\nbody",
+			expected: []string{"infra", "ops"},
+		},
+		{
+			name:     "code blocks are ignored",
+			content:  "```\n#include <stdio.h>\n```\n#c",
+			expected: []string{"c"},
+		},
+		{
+			name:     "anchors in urls are not tags",
+			content:  "see http://example.com/#section",
+			expected: []string{},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, extractTags(tc.content))
+		})
+	}
+}
+
+func TestTagsAndFilters(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "slow queries #perf #db")
+	writeTestNote(t, gn, "billing", "feat/cache", "cache #perf")
+	writeTestNote(t, gn, "other", "main", "nothing to see\ncache")
+
+	tags, err := gn.Tags(NoteFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []TagCount{{Tag: "perf", Count: 2}, {Tag: "db", Count: 1}}, tags)
+
+	notes, err := gn.Notes(NoteFilter{Tag: "#PERF"})
+	assert.NoError(t, err)
+	assert.Len(t, notes, 2)
+
+	results, err := gn.Search("CACHE", NoteFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+
+	results, err = gn.Search("cache", NoteFilter{Tag: "perf"})
+	assert.NoError(t, err)
+	assert.Equal(t, []SearchResult{{Note: NoteRef{"billing", "feat/cache"}, Line: 1, Text: "cache #perf"}}, results)
+}
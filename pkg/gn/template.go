@@ -0,0 +1,123 @@
+package gn
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templatesDir is the directory, inside the notes path, where
+// note templates are kept if TemplatesPath is not set
+const templatesDir = ".templates"
+
+// defaultTemplate is the name of the template used when
+// no template matches the branch prefix
+const defaultTemplate = "default"
+
+// TemplateData holds the variables available to note templates,
+// e.g. {{.Project}} or {{.Ticket}}
+type TemplateData struct {
+	Project string
+	Branch  string
+	// Ticket is the ticket key found in the branch name, e.g. BILL-42
+	Ticket string
+	Author string
+	Email  string
+	// Date is the creation date of the note, as 2006-01-02
+	Date string
+	// Upstream is the upstream of the branch, e.g. origin/feat/login
+	Upstream string
+}
+
+// templatesPath returns the directory holding the note templates
+func (gn *GN) templatesPath() string {
+	if gn.TemplatesPath != "" {
+		return gn.TemplatesPath
+	}
+	return filepath.Join(gn.NotesPath, templatesDir)
+}
+
+// findTemplate returns the path of the template for a new note of ref.
+// Templates are looked up in this order, where prefix is the part of
+// the branch name before the first slash (feat, fix, hotfix, ...):
+//
+//	<project>/<prefix>
+//	<project>/default
+//	<prefix>
+//	default
+//
+// ok is false if there is no template for the note
+func (gn *GN) findTemplate(ref NoteRef) (string, bool) {
+	candidates := []string{}
+	prefix, _, hasPrefix := strings.Cut(ref.Branch, "/")
+	if hasPrefix {
+		candidates = append(candidates, filepath.Join(ref.Project, prefix))
+	}
+	candidates = append(candidates, filepath.Join(ref.Project, defaultTemplate))
+	if hasPrefix {
+		candidates = append(candidates, prefix)
+	}
+	candidates = append(candidates, defaultTemplate)
+
+	for _, c := range candidates {
+		path := filepath.Join(gn.templatesPath(), c)
+		if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// templateData returns the template variables for a new note of ref
+func (gn *GN) templateData(ref NoteRef, now time.Time) TemplateData {
+	data := TemplateData{
+		Project: ref.Project,
+		Branch:  ref.Branch,
+		Ticket:  ticketFromBranch(ref.Branch),
+		Author:  gn.author.Name,
+		Email:   gn.author.Email,
+		Date:    now.Format("2006-01-02"),
+	}
+
+	// the upstream is only known if the note is the one of the working repo
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return data
+	}
+	upstream, err := findUpstream(r, ref.Branch)
+	if err != nil {
+		gn.log.Debug("could not find upstream of %s: %s", ref.Branch, err.Error())
+	}
+	data.Upstream = upstream.Short()
+
+	return data
+}
+
+// renderTemplate renders the template for a new note of ref.
+// It returns an empty string if there is no template for the note
+func (gn *GN) renderTemplate(ref NoteRef) (string, error) {
+	path, ok := gn.findTemplate(ref)
+	if !ok {
+		return "", nil
+	}
+	gn.log.Debug("using template %s", path)
+
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, gn.templateData(ref, time.Now())); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,95 @@
+package gn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTestTemplate writes content into the template name
+func writeTestTemplate(t *testing.T, gn *GN, name string, content string) {
+	path := filepath.Join(gn.templatesPath(), name)
+	err := os.MkdirAll(filepath.Dir(path), os.ModeDir|0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(path, []byte(content), 0644)
+	assert.NoError(t, err)
+}
+
+func TestFindTemplate(t *testing.T) {
+	gn := newTestGN(t)
+
+	_, ok := gn.findTemplate(NoteRef{Project: "billing", Branch: "feat/login"})
+	assert.False(t, ok)
+
+	writeTestTemplate(t, gn, "default", "default")
+	writeTestTemplate(t, gn, "feat", "feat")
+	writeTestTemplate(t, gn, "billing/default", "billing default")
+	writeTestTemplate(t, gn, "billing/fix", "billing fix")
+
+	tt := []struct {
+		name     string
+		ref      NoteRef
+		expected string
+	}{
+		{
+			name:     "project and prefix",
+			ref:      NoteRef{Project: "billing", Branch: "fix/rounding"},
+			expected: "billing/fix",
+		},
+		{
+			name:     "project default before prefix",
+			ref:      NoteRef{Project: "billing", Branch: "feat/login"},
+			expected: "billing/default",
+		},
+		{
+			name:     "prefix",
+			ref:      NoteRef{Project: "shop", Branch: "feat/cart"},
+			expected: "feat",
+		},
+		{
+			name:     "default",
+			ref:      NoteRef{Project: "shop", Branch: "main"},
+			expected: "default",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			path, ok := gn.findTemplate(tc.ref)
+			assert.True(t, ok)
+			assert.Equal(t, filepath.Join(gn.templatesPath(), tc.expected), path)
+		})
+	}
+}
+
+func TestNewNoteContent(t *testing.T) {
+	tr := newTestRepo(t)
+	tr.setUpstream("feat/BILL-42-login", "origin", "feat/BILL-42-login")
+
+	gn := newTestGN(t)
+	ref := NoteRef{Project: "billing", Branch: "feat/BILL-42-login"}
+	writeTestTemplate(t, gn, "feat", "# {{.Ticket}} {{.Project}}/{{.Branch}}\n{{.Author}} {{.Date}} {{.Upstream}}\n")
+
+	content, err := gn.newNoteContent(ref)
+	assert.NoError(t, err)
+	expected := "# BILL-42 billing/feat/BILL-42-login\ntest " + time.Now().Format("2006-01-02") + " origin/feat/BILL-42-login\n"
+	assert.Equal(t, expected, content)
+
+	// the frontmatter goes before the template
+	gn.Frontmatter = true
+	content, err = gn.newNoteContent(ref)
+	assert.NoError(t, err)
+	fm, body, ok := ParseFrontmatter(content)
+	assert.True(t, ok)
+	assert.Equal(t, "\n"+expected, body)
+	ticket, _ := fm.Get("ticket")
+	assert.Equal(t, "BILL-42", ticket)
+
+	// unknown variables are an error
+	writeTestTemplate(t, gn, "feat", "{{.Unknown}}")
+	_, err = gn.newNoteContent(ref)
+	assert.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package gn
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ticketRegex matches issue tracker keys, like BILL-123.
+// Keys start with at least two letters, so v2-1 is not one
+var ticketRegex = regexp.MustCompile(`\b[A-Z]{2,}[A-Z0-9]*-[0-9]+\b`)
+
+// notTickets are prefixes of names that look like ticket keys but are not,
+// like UTF-8, SHA-256, ISO-8601 or rc-1
+var notTickets = map[string]bool{
+	"UTF": true, "SHA": true, "ISO": true, "RFC": true, "CVE": true, "RC": true,
+}
+
+// findTickets returns the ticket keys of s, in order
+func findTickets(s string) []string {
+	tickets := []string{}
+	for _, t := range ticketRegex.FindAllString(s, -1) {
+		prefix, _, _ := strings.Cut(t, "-")
+		if !notTickets[prefix] {
+			tickets = append(tickets, t)
+		}
+	}
+	return tickets
+}
+
+// ticketFromBranch returns the first ticket key of a branch name,
+// e.g. BILL-123 from feat/bill-123-refunds, or an empty string
+func ticketFromBranch(branch string) string {
+	tickets := findTickets(strings.ToUpper(branch))
+	if len(tickets) == 0 {
+		return ""
+	}
+	return tickets[0]
+}
+
+// extractTickets returns the ticket keys of a note, sorted.
+// They are taken from its branch name and from its content
+func extractTickets(ref NoteRef, content string) []string {
+	set := map[string]bool{}
+	if t := ticketFromBranch(ref.Branch); t != "" {
+		set[t] = true
+	}
+	for _, t := range findTickets(content) {
+		set[t] = true
+	}
+
+	tickets := make([]string, 0, len(set))
+	for t := range set {
+		tickets = append(tickets, t)
+	}
+	sort.Strings(tickets)
+	return tickets
+}
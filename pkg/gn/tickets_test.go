@@ -0,0 +1,29 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTicketFromBranch(t *testing.T) {
+	tests := map[string]string{
+		"feat/bill-123-refunds":  "BILL-123",
+		"BILL-7":                 "BILL-7",
+		"fix/ab2-9-crash":        "AB2-9",
+		"release/v2-1":           "",
+		"release/rc-1":           "",
+		"chore/utf-8-bom":        "",
+		"feat/sha-256-then-x-12": "",
+		"main":                   "",
+	}
+	for branch, expected := range tests {
+		assert.Equal(t, expected, ticketFromBranch(branch), branch)
+	}
+}
+
+func TestExtractTickets(t *testing.T) {
+	content := "Fixes BILL-12 and OPS-3.\nFiles are UTF-8, hashed with SHA-256, dates in ISO-8601 (see RFC-3339). Not a ticket: A-1.\n"
+	tickets := extractTickets(NoteRef{Project: "billing", Branch: "feat/bill-40"}, content)
+	assert.Equal(t, []string{"BILL-12", "BILL-40", "OPS-3"}, tickets)
+}
@@ -0,0 +1,318 @@
+package gn
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// metaDir is the directory, inside the notes path, where gn keeps
+// its own data, so that it is synced along with the notes
+const metaDir = ".gn"
+
+// timeLogFile is the file, inside metaDir, where sessions are logged
+const timeLogFile = "time.log"
+
+// maxCheckoutSpan is the longest time a checkout counts for.
+// Branches stay checked out overnight, which is not time spent on them
+const maxCheckoutSpan = 4 * time.Hour
+
+// SessionKind tells how a session was recorded
+type SessionKind string
+
+const (
+	// SessionEdit is the time a note was open in the editor
+	SessionEdit SessionKind = "edit"
+	// SessionCheckout is the time a branch was checked out, from the reflog
+	SessionCheckout SessionKind = "checkout"
+)
+
+// Session is a span of time spent on the branch of a note
+type Session struct {
+	NoteRef
+	Kind  SessionKind
+	Start time.Time
+	End   time.Time
+}
+
+// String returns the session as a line of the time log
+func (s Session) String() string {
+	return strings.Join([]string{
+		s.Start.Format(time.RFC3339), s.End.Format(time.RFC3339), string(s.Kind), s.Project, s.Branch,
+	}, "\t")
+}
+
+// parseSession parses a line of the time log
+func parseSession(line string) (Session, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) != 5 {
+		return Session{}, errflags.New("invalid time log line: "+line, errflags.BadParameter)
+	}
+	start, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return Session{}, err
+	}
+	end, err := time.Parse(time.RFC3339, fields[1])
+	if err != nil {
+		return Session{}, err
+	}
+	return Session{
+		NoteRef: NoteRef{Project: fields[3], Branch: fields[4]},
+		Kind:    SessionKind(fields[2]),
+		Start:   start,
+		End:     end,
+	}, nil
+}
+
+// timeLogPath returns the path of the time log
+func (gn *GN) timeLogPath() string {
+	return filepath.Join(gn.NotesPath, metaDir, timeLogFile)
+}
+
+// logSessions appends sessions to the time log and commits it,
+// so it syncs with the notes even if AlwaysCommit is not set
+func (gn *GN) logSessions(sessions ...Session) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	path := gn.timeLogPath()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModeDir|0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		if _, err := fmt.Fprintln(f, s); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return gn.commitPaths("Update time log", filepath.Join(metaDir, timeLogFile))
+}
+
+// readSessions returns the sessions of the time log
+func (gn *GN) readSessions() ([]Session, error) {
+	f, err := os.Open(gn.timeLogPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Session{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	sessions := []Session{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		s, err := parseSession(scanner.Text())
+		if err != nil {
+			gn.log.Debug("skipping time log line: %s", err.Error())
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, scanner.Err()
+}
+
+// ImportCheckouts adds to the time log the time each branch of the working
+// repository was checked out, read from its HEAD reflog. Each checkout counts
+// until the next one, up to maxCheckoutSpan. Checkouts already in the log are
+// skipped. It returns the added sessions
+func (gn *GN) ImportCheckouts() ([]Session, error) {
+	project, err := gn.findProject()
+	if err != nil {
+		return nil, err
+	}
+	r, err := gn.openWorkingRepo()
+	if err != nil {
+		return nil, err
+	}
+	storage, ok := r.Storer.(*filesystem.Storage)
+	if !ok {
+		return nil, errflags.New("working repository has no reflog", errflags.NotFound)
+	}
+
+	f, err := storage.Filesystem().Open(filepath.Join("logs", "HEAD"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Session{}, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	checkouts, err := parseCheckouts(bufio.NewScanner(f), project, func(target string, to string) bool {
+		return isBranchCheckout(r, target, to)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logged, err := gn.readSessions()
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]bool{}
+	for _, s := range logged {
+		seen[s.String()] = true
+	}
+
+	added := []Session{}
+	for _, s := range checkouts {
+		if !seen[s.String()] {
+			added = append(added, s)
+		}
+	}
+	return added, gn.logSessions(added...)
+}
+
+// parseCheckouts returns the sessions of the checkouts of a reflog.
+// isBranch tells whether the target of a checkout, given the hash it moved
+// to, is a branch; other checkouts end the previous session without starting
+// a new one. The last checkout is left out, since it has not ended yet
+func parseCheckouts(scanner *bufio.Scanner, project string, isBranch func(target string, to string) bool) ([]Session, error) {
+	sessions := []Session{}
+	var current *Session
+	for scanner.Scan() {
+		// <old> <new> <name> <<email>> <unix time> <zone>\t<message>
+		entry, msg, ok := strings.Cut(scanner.Text(), "\t")
+		if !ok {
+			continue
+		}
+		branch, ok := strings.CutPrefix(msg, "checkout: moving from ")
+		if !ok {
+			continue
+		}
+		_, branch, ok = strings.Cut(branch, " to ")
+		if !ok {
+			continue
+		}
+
+		fields := strings.Fields(entry)
+		if len(fields) < 2 {
+			continue
+		}
+		unix, err := strconv.ParseInt(fields[len(fields)-2], 10, 64)
+		if err != nil {
+			continue
+		}
+		when := time.Unix(unix, 0)
+
+		if current != nil {
+			current.End = when
+			if current.End.Sub(current.Start) > maxCheckoutSpan {
+				current.End = current.Start.Add(maxCheckoutSpan)
+			}
+			sessions = append(sessions, *current)
+			current = nil
+		}
+		if !isBranch(branch, fields[1]) {
+			continue
+		}
+		current = &Session{NoteRef: NoteRef{Project: project, Branch: branch}, Kind: SessionCheckout, Start: when}
+	}
+	return sessions, scanner.Err()
+}
+
+// isBranchCheckout reports whether target, a checkout of r that moved HEAD
+// to the commit to, is a local branch rather than a detached HEAD: a commit,
+// a tag or a remote branch. Targets that no longer exist are taken as
+// branches deleted since, so that the time spent on them still counts
+func isBranchCheckout(r *git.Repository, target string, to string) bool {
+	if _, err := r.Reference(plumbing.NewBranchReferenceName(target), false); err == nil {
+		return true
+	}
+	// revisions like HEAD~2 or main@{1} are not valid branch names
+	if target == "HEAD" || strings.ContainsAny(target, "~^:") || strings.Contains(target, "@{") {
+		return false
+	}
+	if len(target) >= 4 && strings.HasPrefix(to, strings.ToLower(target)) {
+		return false
+	}
+	if _, err := r.Reference(plumbing.NewTagReferenceName(target), false); err == nil {
+		return false
+	}
+	if _, err := r.Reference(plumbing.ReferenceName("refs/remotes/"+target), false); err == nil {
+		return false
+	}
+	return true
+}
+
+// TimeEntry is the time spent on the branch of a note, by kind of session
+type TimeEntry struct {
+	NoteRef
+	Edit     time.Duration
+	Checkout time.Duration
+}
+
+// TimeSpent returns the time spent on each branch of project, or of all
+// projects if project is empty, between since and until.
+// Sessions are cut to the range. A zero until means now
+func (gn *GN) TimeSpent(project string, since time.Time, until time.Time) ([]TimeEntry, error) {
+	if until.IsZero() {
+		until = time.Now()
+	}
+
+	sessions, err := gn.readSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	byRef := map[NoteRef]*TimeEntry{}
+	for _, s := range sessions {
+		if project != "" && s.Project != project {
+			continue
+		}
+		start, end := s.Start, s.End
+		if start.Before(since) {
+			start = since
+		}
+		if end.After(until) {
+			end = until
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		e, ok := byRef[s.NoteRef]
+		if !ok {
+			e = &TimeEntry{NoteRef: s.NoteRef}
+			byRef[s.NoteRef] = e
+		}
+		switch s.Kind {
+		case SessionEdit:
+			e.Edit += end.Sub(start)
+		case SessionCheckout:
+			e.Checkout += end.Sub(start)
+		}
+	}
+
+	entries := []TimeEntry{}
+	for _, e := range byRef {
+		entries = append(entries, *e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].String() < entries[j].String()
+	})
+	return entries, nil
+}
@@ -0,0 +1,129 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeSpent(t *testing.T) {
+	gn := newTestGN(t)
+	day := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	login := NoteRef{Project: "billing", Branch: "feat/login"}
+
+	err := gn.logSessions(
+		Session{NoteRef: login, Kind: SessionEdit, Start: day, End: day.Add(30 * time.Minute)},
+		Session{NoteRef: login, Kind: SessionCheckout, Start: day, End: day.Add(2 * time.Hour)},
+		// cut by the range
+		Session{NoteRef: login, Kind: SessionEdit, Start: day.Add(-time.Hour), End: day.Add(10 * time.Minute)},
+		Session{NoteRef: NoteRef{Project: "shop", Branch: "main"}, Kind: SessionEdit, Start: day, End: day.Add(time.Hour)},
+	)
+	assert.NoError(t, err)
+
+	// the log is commited to sync across machines
+	r, err := git.PlainOpen(gn.NotesPath)
+	assert.NoError(t, err)
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	status, err := w.Status()
+	assert.NoError(t, err)
+	assert.True(t, status.IsClean(), status.String())
+
+	entries, err := gn.TimeSpent("", day, day.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, []TimeEntry{
+		{NoteRef: login, Edit: 40 * time.Minute, Checkout: 2 * time.Hour},
+		{NoteRef: NoteRef{Project: "shop", Branch: "main"}, Edit: time.Hour},
+	}, entries)
+
+	entries, err = gn.TimeSpent("shop", day, day.Add(24*time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestImportCheckouts(t *testing.T) {
+	tr := newTestRepo(t)
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC).Unix()
+	zero := "0000000000000000000000000000000000000000"
+	reflog := ""
+	for i, msg := range []string{
+		"commit (initial): Initial commit",
+		"checkout: moving from main to feat/login",
+		"checkout: moving from feat/login to main",
+		"checkout: moving from main to feat/login",
+	} {
+		// one hour apart, but the last checkout comes the next day
+		at := start + int64(i)*3600
+		if i == 3 {
+			at += 24 * 3600
+		}
+		reflog += fmt.Sprintf("%s %s test <test@example.com> %d +0000\t%s\n", zero, zero, at, msg)
+	}
+	// go-git does not write the reflog
+	err := os.MkdirAll(filepath.Join(tr.dir, ".git", "logs"), os.ModeDir|0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tr.dir, ".git", "logs", "HEAD"), []byte(reflog), 0644)
+	assert.NoError(t, err)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	added, err := gn.ImportCheckouts()
+	assert.NoError(t, err)
+	assert.Len(t, added, 2)
+	assert.Equal(t, "feat/login", added[0].Branch)
+	assert.Equal(t, time.Hour, added[0].End.Sub(added[0].Start))
+	assert.Equal(t, "main", added[1].Branch)
+	assert.Equal(t, maxCheckoutSpan, added[1].End.Sub(added[1].Start))
+
+	// importing again adds nothing
+	added, err = gn.ImportCheckouts()
+	assert.NoError(t, err)
+	assert.Empty(t, added)
+	sessions, err := gn.readSessions()
+	assert.NoError(t, err)
+	assert.Len(t, sessions, 2)
+}
+
+func TestImportCheckoutsSkipsDetachedHead(t *testing.T) {
+	tr := newTestRepo(t)
+	h := tr.commitFile("README.md", "# billing\n", "Initial commit")
+	_, err := tr.repo.CreateTag("v1.0", h, nil)
+	assert.NoError(t, err)
+	tr.checkout("feat/login", true)
+	tr.checkout("main", false)
+
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC).Unix()
+	sha := h.String()
+	reflog := ""
+	for i, msg := range []string{
+		"checkout: moving from main to feat/login",
+		"checkout: moving from feat/login to " + sha[:7],
+		"checkout: moving from " + sha[:7] + " to v1.0",
+		"checkout: moving from v1.0 to HEAD~1",
+		"checkout: moving from HEAD~1 to feat/deleted",
+		"checkout: moving from feat/deleted to main",
+	} {
+		reflog += fmt.Sprintf("%s %s test <test@example.com> %d +0000\t%s\n", sha, sha, start+int64(i)*600, msg)
+	}
+	err = os.MkdirAll(filepath.Join(tr.dir, ".git", "logs"), os.ModeDir|0700)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(tr.dir, ".git", "logs", "HEAD"), []byte(reflog), 0644)
+	assert.NoError(t, err)
+
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	added, err := gn.ImportCheckouts()
+	assert.NoError(t, err)
+	branches := []string{}
+	for _, s := range added {
+		branches = append(branches, s.Branch)
+		assert.Equal(t, 10*time.Minute, s.End.Sub(s.Start))
+	}
+	// deleted branches still count
+	assert.Equal(t, []string{"feat/login", "feat/deleted"}, branches)
+}
@@ -0,0 +1,115 @@
+package gn
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// checkboxRegex matches Markdown checklist items, like "- [ ] write docs"
+var checkboxRegex = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\]\s+)(.*)$`)
+
+// TodoItem is a checklist item of a note
+type TodoItem struct {
+	Note NoteRef
+	// Line is the 1-based line number of the item in the note
+	Line int
+	Text string
+	Done bool
+}
+
+// Ref returns the reference of the item, in the form project/branch:line
+func (t TodoItem) Ref() string {
+	return fmt.Sprintf("%s:%d", t.Note, t.Line)
+}
+
+// ParseTodoRef parses a todo reference in the form project/branch:line
+func ParseTodoRef(s string) (NoteRef, int, error) {
+	i := strings.LastIndex(s, ":")
+	if i < 0 {
+		return NoteRef{}, 0, errflags.New("todo reference must be in the form project/branch:line", errflags.BadParameter)
+	}
+
+	line, err := strconv.Atoi(s[i+1:])
+	if err != nil || line < 1 {
+		return NoteRef{}, 0, errflags.New("invalid line number in todo reference "+s, errflags.BadParameter)
+	}
+
+	ref, err := ParseNoteRef(s[:i])
+	if err != nil {
+		return NoteRef{}, 0, err
+	}
+	return ref, line, nil
+}
+
+// Todos returns the open checklist items of the notes selected by filter
+func (gn *GN) Todos(filter NoteFilter) ([]TodoItem, error) {
+	notes, err := gn.loadNotes(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	todos := []TodoItem{}
+	for _, n := range notes {
+		for _, t := range parseTodos(n.NoteRef, n.Content) {
+			if !t.Done {
+				todos = append(todos, t)
+			}
+		}
+	}
+
+	return todos, nil
+}
+
+// CompleteTodo ticks the checklist item at line of the note
+// and commits the change
+func (gn *GN) CompleteTodo(ref NoteRef, line int) (TodoItem, error) {
+	content, err := gn.readNote(ref)
+	if err != nil {
+		return TodoItem{}, err
+	}
+
+	lines := strings.Split(content, "\n")
+	if line > len(lines) {
+		return TodoItem{}, errflags.New(fmt.Sprintf("note %s has no line %d", ref, line), errflags.NotFound)
+	}
+
+	m := checkboxRegex.FindStringSubmatch(lines[line-1])
+	if m == nil {
+		return TodoItem{}, errflags.New(fmt.Sprintf("line %d of %s is not a checklist item", line, ref), errflags.BadParameter)
+	}
+	item := TodoItem{Note: ref, Line: line, Text: m[4], Done: true}
+	if m[2] != " " {
+		return item, errflags.New(fmt.Sprintf("%s is already done", item.Ref()), errflags.BadParameter)
+	}
+
+	lines[line-1] = m[1] + "x" + m[3] + m[4]
+	if err := os.WriteFile(ref.path(gn.NotesPath), []byte(strings.Join(lines, "\n")), 0644); err != nil {
+		return TodoItem{}, err
+	}
+
+	return item, gn.commitPaths(fmt.Sprintf("Complete todo %s", item.Ref()), filepath.Join(ref.Project, ref.Branch))
+}
+
+// parseTodos returns the checklist items of the note content
+func parseTodos(ref NoteRef, content string) []TodoItem {
+	todos := []TodoItem{}
+	for i, line := range strings.Split(content, "\n") {
+		m := checkboxRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		todos = append(todos, TodoItem{
+			Note: ref,
+			Line: i + 1,
+			Text: strings.TrimSpace(m[4]),
+			Done: m[2] != " ",
+		})
+	}
+	return todos
+}
@@ -0,0 +1,43 @@
+package gn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodos(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "# Main\n- [ ] write docs\n- [x] fix bug\n* [ ] review PR\n")
+	writeTestNote(t, gn, "other", "feat/x", "- [ ] other todo\n")
+
+	todos, err := gn.Todos(NoteFilter{Project: "billing"})
+	assert.NoError(t, err)
+	assert.Equal(t, []TodoItem{
+		{Note: NoteRef{"billing", "main"}, Line: 2, Text: "write docs"},
+		{Note: NoteRef{"billing", "main"}, Line: 4, Text: "review PR"},
+	}, todos)
+
+	todos, err = gn.Todos(NoteFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, todos, 3)
+	assert.Equal(t, "other/feat/x:1", todos[2].Ref())
+}
+
+func TestCompleteTodo(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "feat/x", "- [ ] write docs\n- [x] fix bug\ntext\n")
+
+	ref, line, err := ParseTodoRef("billing/feat/x:1")
+	assert.NoError(t, err)
+
+	item, err := gn.CompleteTodo(ref, line)
+	assert.NoError(t, err)
+	assert.Equal(t, "write docs", item.Text)
+	assert.Equal(t, "- [x] write docs\n- [x] fix bug\ntext\n", readTestNote(t, gn, "billing", "feat/x"))
+
+	_, err = gn.CompleteTodo(ref, 2)
+	assert.Error(t, err, "already done items can't be completed")
+	_, err = gn.CompleteTodo(ref, 3)
+	assert.Error(t, err, "lines that are not checklist items can't be completed")
+}
@@ -0,0 +1,221 @@
+package gn
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+)
+
+// trashDir is the directory, inside the notes path,
+// where deleted notes are kept until the trash is emptied
+const trashDir = ".trash"
+
+// trashNoteFile and trashMetaFile are the files inside
+// each trash item directory
+const (
+	trashNoteFile = "note"
+	trashMetaFile = "meta"
+)
+
+// TrashItem is a note that was deleted
+type TrashItem struct {
+	// ID identifies the item in the trash
+	ID      string
+	Project string
+	Branch  string
+	Deleted time.Time
+}
+
+// Ref returns the reference of the note before it was deleted
+func (t TrashItem) Ref() NoteRef {
+	return NoteRef{Project: t.Project, Branch: t.Branch}
+}
+
+// trash moves the note into the trash
+func (gn *GN) trash(ref NoteRef) (TrashItem, error) {
+	notePath := ref.path(gn.NotesPath)
+	if _, err := os.Stat(notePath); err != nil {
+		if os.IsNotExist(err) {
+			return TrashItem{}, errflags.Flag(fmt.Errorf("note %s not found", ref), errflags.NotFound)
+		}
+		return TrashItem{}, err
+	}
+
+	now := time.Now()
+	item := TrashItem{
+		ID:      trashID(ref, now),
+		Project: ref.Project,
+		Branch:  ref.Branch,
+		Deleted: now,
+	}
+
+	itemPath := filepath.Join(gn.NotesPath, trashDir, item.ID)
+	if err := os.MkdirAll(itemPath, os.ModeDir|0700); err != nil {
+		return TrashItem{}, err
+	}
+
+	err := writeKeyValues(filepath.Join(itemPath, trashMetaFile),
+		[2]string{"project", item.Project},
+		[2]string{"branch", item.Branch},
+		[2]string{"deleted", item.Deleted.Format(time.RFC3339)},
+	)
+	if err != nil {
+		return TrashItem{}, err
+	}
+
+	gn.log.Debug("moving %s to trash as %s", notePath, item.ID)
+	if err := os.Rename(notePath, filepath.Join(itemPath, trashNoteFile)); err != nil {
+		return TrashItem{}, err
+	}
+	removeEmptyParents(notePath, filepath.Join(gn.NotesPath, ref.Project))
+
+	return item, nil
+}
+
+// DeleteProject moves all notes of project into the trash
+func (gn *GN) DeleteProject(project string) ([]TrashItem, error) {
+	notes, err := gn.listNotes(project)
+	if err != nil {
+		return nil, err
+	}
+	if len(notes) == 0 {
+		return nil, errflags.New(fmt.Sprintf("project %s has no notes", project), errflags.NotFound)
+	}
+
+	items := []TrashItem{}
+	for _, n := range notes {
+		item, err := gn.trash(n)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, item)
+	}
+
+	// remove the project directory if nothing else was left in it
+	_ = os.Remove(filepath.Join(gn.NotesPath, project))
+
+	return items, nil
+}
+
+// ListTrash returns the items in the trash, most recently deleted first
+func (gn *GN) ListTrash() ([]TrashItem, error) {
+	entries, err := os.ReadDir(filepath.Join(gn.NotesPath, trashDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []TrashItem{}, nil
+		}
+		return nil, err
+	}
+
+	items := []TrashItem{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		item, err := gn.readTrashItem(e.Name())
+		if err != nil {
+			gn.log.Debug("skipping trash item %s: %s", e.Name(), err.Error())
+			continue
+		}
+		items = append(items, item)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Deleted.After(items[j].Deleted)
+	})
+
+	return items, nil
+}
+
+// Restore moves the trash item with the given id back to its project and branch.
+// It fails if a note already exists there
+func (gn *GN) Restore(id string) (TrashItem, error) {
+	item, err := gn.readTrashItem(id)
+	if err != nil {
+		return TrashItem{}, err
+	}
+
+	notePath := item.Ref().path(gn.NotesPath)
+	if _, err := os.Stat(notePath); err == nil {
+		return TrashItem{}, errflags.New(fmt.Sprintf("note %s already exists", item.Ref()), errflags.BadParameter)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(notePath), os.ModeDir|0700); err != nil {
+		return TrashItem{}, err
+	}
+
+	itemPath := filepath.Join(gn.NotesPath, trashDir, id)
+	if err := os.Rename(filepath.Join(itemPath, trashNoteFile), notePath); err != nil {
+		return TrashItem{}, err
+	}
+
+	return item, os.RemoveAll(itemPath)
+}
+
+// EmptyTrash permanently removes the trash items deleted more than olderThan ago.
+// A zero olderThan removes every item
+func (gn *GN) EmptyTrash(olderThan time.Duration) ([]TrashItem, error) {
+	items, err := gn.ListTrash()
+	if err != nil {
+		return nil, err
+	}
+
+	removed := []TrashItem{}
+	for _, item := range items {
+		if time.Since(item.Deleted) < olderThan {
+			continue
+		}
+		gn.log.Debug("removing trash item %s", item.ID)
+		if err := os.RemoveAll(filepath.Join(gn.NotesPath, trashDir, item.ID)); err != nil {
+			return removed, err
+		}
+		removed = append(removed, item)
+	}
+
+	return removed, nil
+}
+
+// readTrashItem reads the metadata of the trash item with the given id
+func (gn *GN) readTrashItem(id string) (TrashItem, error) {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return TrashItem{}, errflags.New("invalid trash id", errflags.BadParameter)
+	}
+
+	values, err := readKeyValues(filepath.Join(gn.NotesPath, trashDir, id, trashMetaFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return TrashItem{}, errflags.Flag(fmt.Errorf("trash item %s not found", id), errflags.NotFound)
+		}
+		return TrashItem{}, err
+	}
+
+	deleted, err := time.Parse(time.RFC3339, values["deleted"])
+	if err != nil {
+		return TrashItem{}, err
+	}
+	// the note is restored to this reference, it must stay inside the notes path
+	ref, err := ParseNoteRef(values["project"] + "/" + values["branch"])
+	if err != nil {
+		return TrashItem{}, fmt.Errorf("trash item %s: %w", id, err)
+	}
+
+	return TrashItem{
+		ID:      id,
+		Project: ref.Project,
+		Branch:  ref.Branch,
+		Deleted: deleted,
+	}, nil
+}
+
+// trashID returns a short id for a note deleted at the given time
+func trashID(ref NoteRef, at time.Time) string {
+	h := sha1.Sum([]byte(fmt.Sprintf("%s@%d", ref, at.UnixNano())))
+	return hex.EncodeToString(h[:])[:8]
+}
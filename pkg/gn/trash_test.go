@@ -0,0 +1,101 @@
+package gn
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mcbattirola/gitnotes/pkg/errflags"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteAndRestore(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/refunds"
+	writeTestNote(t, gn, "billing", "feat/refunds", "refund notes")
+
+	item, err := gn.Delete()
+	assert.NoError(t, err)
+	assert.Equal(t, NoteRef{Project: "billing", Branch: "feat/refunds"}, item.Ref())
+
+	_, err = os.Stat(getNotePath(gn.NotesPath, "billing", "feat/refunds"))
+	assert.True(t, os.IsNotExist(err))
+
+	items, err := gn.ListTrash()
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, item.ID, items[0].ID)
+	assert.Equal(t, "billing", items[0].Project)
+	assert.Equal(t, "feat/refunds", items[0].Branch)
+
+	// the trash is not listed as a note
+	notes, err := gn.listNotes("")
+	assert.NoError(t, err)
+	assert.Empty(t, notes)
+
+	_, err = gn.Restore(item.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "refund notes", readTestNote(t, gn, "billing", "feat/refunds"))
+
+	items, err = gn.ListTrash()
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestDeleteProjectAndEmptyTrash(t *testing.T) {
+	gn := newTestGN(t)
+	writeTestNote(t, gn, "billing", "main", "main notes")
+	writeTestNote(t, gn, "billing", "feat/refunds", "refund notes")
+	writeTestNote(t, gn, "other", "main", "other notes")
+
+	items, err := gn.DeleteProject("billing")
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	notes, err := gn.listNotes("")
+	assert.NoError(t, err)
+	assert.Equal(t, []NoteRef{{Project: "other", Branch: "main"}}, notes)
+
+	// nothing is older than an hour
+	removed, err := gn.EmptyTrash(time.Hour)
+	assert.NoError(t, err)
+	assert.Empty(t, removed)
+
+	removed, err = gn.EmptyTrash(0)
+	assert.NoError(t, err)
+	assert.Len(t, removed, 2)
+
+	items, err = gn.ListTrash()
+	assert.NoError(t, err)
+	assert.Empty(t, items)
+}
+
+func TestRestoreRejectsPathsOutsideNotes(t *testing.T) {
+	gn := newTestGN(t)
+	gn.Project = "billing"
+	gn.Branch = "feat/refunds"
+	writeTestNote(t, gn, "billing", "feat/refunds", "refund notes")
+
+	item, err := gn.Delete()
+	assert.NoError(t, err)
+	err = writeKeyValues(filepath.Join(gn.NotesPath, trashDir, item.ID, trashMetaFile),
+		[2]string{"project", ".."},
+		[2]string{"branch", ".."},
+		[2]string{"deleted", item.Deleted.Format(time.RFC3339)},
+	)
+	assert.NoError(t, err)
+
+	_, err = gn.Restore(item.ID)
+	assert.True(t, errflags.HasFlag(err, errflags.BadParameter), err)
+	_, err = os.Stat(filepath.Join(gn.NotesPath, trashDir, item.ID, trashNoteFile))
+	assert.NoError(t, err)
+
+	for _, id := range []string{".", ".."} {
+		_, err = gn.Restore(id)
+		assert.True(t, errflags.HasFlag(err, errflags.BadParameter), id)
+	}
+	_, err = os.Stat(filepath.Join(gn.NotesPath, trashDir))
+	assert.NoError(t, err)
+}